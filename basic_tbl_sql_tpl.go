@@ -1,6 +1,9 @@
 package dbsql2go
 
-import "text/template"
+import (
+	"fmt"
+	"text/template"
+)
 
 // the basic sql stuff for a single table go in this file.
 
@@ -15,8 +18,13 @@ var (
 	// return a slice of results; not as a method on a table.
 	SelectAndOrSQL *template.Template
 	DeleteSQL      *template.Template // Template to DELETE from a single table with only ANDs
-	InsertSQL      *template.Template // Template to INSERT into a single table with only ANDs
+	InsertSQL      *template.Template // Template to INSERT into a single table with only ANDs; see insertSQL for its named templates
 	UpdateSQL      *template.Template // Template to UPDATE a row in a single table with only ANDs
+	// OnDuplicateKeySQL is the ON DUPLICATE KEY UPDATE clause, including its
+	// leading space, that is appended to an INSERT to make it an upsert. If
+	// RowAlias is set, the new row is aliased and its columns are referred
+	// to using the alias; otherwise VALUES() is used.
+	OnDuplicateKeySQL *template.Template
 
 	// Comment fragments
 	SelectAndOrWhereComment *template.Template // The WHERE clause comment fragment for AndOR SQL funcs.
@@ -25,12 +33,14 @@ var (
 func init() {
 	funcMap := template.FuncMap{
 		"minusOne": minusOne,
+		"argOf":    argOf,
 	}
 	SelectSQL = template.Must(template.New("select").Parse(selectSQL))
 	SelectAndOrSQL = template.Must(template.New("selectandor").Funcs(funcMap).Parse(selectAndOrSQL))
 	DeleteSQL = template.Must(template.New("delete").Parse(deleteSQL))
 	InsertSQL = template.Must(template.New("insert").Parse(insertSQL))
 	UpdateSQL = template.Must(template.New("update").Parse(updateSQL))
+	OnDuplicateKeySQL = template.Must(template.New("onduplicatekey").Parse(onDuplicateKeySQL))
 
 	SelectAndOrWhereComment = template.Must(template.New("selectandorcomment").Funcs(funcMap).Parse(selectAndOrWhereComment))
 }
@@ -39,10 +49,20 @@ func minusOne(i int) int {
 	return i - 1
 }
 
+// argOf returns the name of the argument for the i-th comparison in a WHERE
+// clause comment: the i-th WhereArgs or, if there aren't any, arg[i].
+func argOf(t TableSQL, i int) string {
+	if i < len(t.WhereArgs) {
+		return t.WhereArgs[i]
+	}
+	return fmt.Sprintf("arg[%d]", i)
+}
+
 // selectSQL is the template for selecting data from a single table. All
 // columns in the WHERE field are assumed to use AND. Support for other
 // conditions may be added in the future, but it complicates things, and,
 // initially, this is meant to just create the basic SELECTs from a table.
+// Each of the WhereNull columns must be NULL.
 var selectSQL = `{{ if and (ne .Table "") (gt (len .Columns) 0) -}}
 SELECT
 {{- range $i, $col := .Columns -}}
@@ -51,11 +71,14 @@ SELECT
 		, {{$col}}
 	{{- end -}}
 {{- end }} FROM {{.Table}}
-{{- if gt (len .WhereColumns) 0 }} WHERE {{- range $i, $col := .WhereColumns -}}
+{{- if or (gt (len .WhereColumns) 0) (gt (len .WhereNull) 0) }} WHERE {{- range $i, $col := .WhereColumns -}}
 	{{- if eq $i 0 }} {{ $col }} = ?
 	{{- else }} AND {{ $col }} = ?
 	{{- end -}}
 {{- end -}}
+{{- range $i, $col := .WhereNull -}}
+	{{- if or (gt $i 0) (gt (len $.WhereColumns) 0) }} AND{{ end }} {{ $col }} IS NULL
+{{- end -}}
 {{- end -}}
 {{- end -}}
 `
@@ -66,8 +89,31 @@ SELECT
 // inclusive evaluations can be done but this also can do more general
 // comparisons limited by the limited logic of the WHERE clause generation
 // in the template.
+//
+// If WhereTuple is set, the WhereColumns are compared as a row constructor by
+// each of the WhereComparisonOps, e.g. (a, b) > (?, ?) AND (a, b) < (?, ?),
+// which compares the columns in order, like an ORDER BY, instead of each
+// column separately.
+//
+// Each of the WhereNull columns must be NULL: they are ANDed with the
+// comparisons.
 var selectAndOrSQL = `{{ $ComparisonMinus := minusOne (len .WhereComparisonOps) -}}
-{{ if and (ne .Table "") (and (gt (len .Columns) 0) (and (gt (len .WhereColumns) 0) (and (eq (len .WhereColumns) (len .WhereComparisonOps)) (and (eq (len .WhereConditions) $ComparisonMinus))))) -}}
+{{ if .WhereTuple -}}
+{{ if and (ne .Table "") (and (gt (len .Columns) 0) (and (gt (len .WhereColumns) 0) (and (gt (len .WhereComparisonOps) 0) (eq (len .WhereConditions) $ComparisonMinus)))) -}}
+SELECT
+{{- range $i, $col := .Columns -}}
+	{{- if eq $i 0 }} {{ $col -}}
+	{{- else -}}
+		, {{$col}}
+	{{- end -}}
+{{- end }} FROM {{ .Table }} WHERE {{- range $i, $op := .WhereComparisonOps -}}
+	{{- if gt $i 0 }} {{ index $.WhereConditions (minusOne $i) }}{{ end }} {{ template "tuple" $.WhereColumns }} {{ $op }} (
+	{{- range $j, $col := $.WhereColumns }}{{ if gt $j 0 }}, {{ end }}?{{ end -}}
+	)
+{{- end -}}
+{{- template "isnull" . -}}
+{{- end -}}
+{{- else if and (ne .Table "") (and (gt (len .Columns) 0) (and (gt (len .WhereColumns) 0) (and (eq (len .WhereColumns) (len .WhereComparisonOps)) (and (eq (len .WhereConditions) $ComparisonMinus))))) -}}
 SELECT
 {{- range $i, $col := .Columns -}}
 	{{- if eq $i 0 }} {{ $col -}}
@@ -79,6 +125,13 @@ SELECT
 	{{- else }} {{index $.WhereConditions (minusOne ($i))}} {{ $col }} {{ index $.WhereComparisonOps $i }} ?
 	{{- end -}}
 {{- end -}}
+{{- template "isnull" . -}}
+{{- end -}}
+{{- define "tuple" -}}
+({{ range $i, $col := . }}{{ if gt $i 0 }}, {{ end }}{{ $col }}{{ end }})
+{{- end -}}
+{{- define "isnull" -}}
+{{ range $col := .WhereNull }} AND {{ $col }} IS NULL{{ end }}
 {{- end -}}
 `
 
@@ -86,29 +139,43 @@ SELECT
 // columns in the WHERE field are assumed to use AND. Support for other
 // conditions may be added in the future, but it complicates things, and,
 // initially, this is meant to just create the basic DELETEs from a table.
+// If WhereNullSafe is set, the columns are compared using <=>.
 var deleteSQL = `{{ if ne .Table "" -}}
 DELETE FROM {{.Table}}
 {{- if gt (len .WhereColumns) 0 }} WHERE {{- range $i, $col := .WhereColumns -}}
-	{{- if eq $i 0 }} {{ $col }} = ?
-	{{- else }} AND {{ $col }} = ?
+	{{- if eq $i 0 }} {{ $col }} {{ template "eq" $ }} ?
+	{{- else }} AND {{ $col }} {{ template "eq" $ }} ?
 	{{- end -}}
 {{- end -}}
 {{- end -}}
+{{- template "limit" . -}}
 {{- end -}}
+{{- define "eq" }}{{ if .WhereNullSafe }}<=>{{ else }}={{ end }}{{ end -}}
+{{- define "limit" }}{{ if gt .Limit 0 }} LIMIT {{ .Limit }}{{ end }}{{ end -}}
 `
 
 // insertSQL is the template for inserting data from a single table. All
 // columns in the WHERE field are assumed to use AND. Support for other
 // conditions may be added in the future, but it complicates things, and,
 // initially, this is meant to just create the basic INSERTs into a table.
+//
+// The INSERT is made up of two named templates so that multi-row INSERTs can
+// be built: "insertinto", the statement up to and including VALUES, and
+// "insertvalues", the placeholders for one row.
 var insertSQL = `{{ if and (ne .Table "") (gt (len .Columns) 0) -}}
+{{ template "insertinto" . }} {{ template "insertvalues" . }}
+{{- end -}}
+{{- define "insertinto" -}}
 INSERT INTO {{.Table}} (
 {{- range $i, $col := .Columns -}}
 	{{- if eq $i 0 }}{{ $col -}}
 	{{- else -}}, {{$col}}
 	{{- end -}}
 {{- end -}}
-) VALUES ({{- range $i, $col := .Columns -}}
+) VALUES
+{{- end -}}
+{{- define "insertvalues" -}}
+({{- range $i, $col := .Columns -}}
 {{- if eq $i 0 -}} ?
 {{- else }}, ?
 {{- end -}}
@@ -121,29 +188,71 @@ INSERT INTO {{.Table}} (
 // columns in the WHERE field are assumed to use AND. Support for other
 // conditions may be added in the future, but it complicates things, and,
 // initially, this is meant to just create the basic UPDATES to a table row.
+// Each of the IncrementColumns is incremented by 1. If WhereNullSafe is set,
+// the WHERE columns are compared using <=>.
 var updateSQL = `{{ if and (ne .Table "") (gt (len .Columns) 0) -}}
 UPDATE {{.Table}} SET {{ range $i, $col := .Columns -}}
 	{{- if eq $i 0 }}{{ $col }} = ?
 	{{- else -}}, {{$col}} = ?
 	{{- end -}}
 {{- end -}}
+{{- range $col := .IncrementColumns -}}, {{ $col }} = {{ $col }} + 1{{- end -}}
 {{ if gt (len .WhereColumns) 0 }} WHERE {{- range $i, $col := .WhereColumns -}}
-	{{- if eq $i 0 }} {{ $col }} = ?
-	{{- else }} AND {{ $col }} = ?
+	{{- if eq $i 0 }} {{ $col }} {{ template "eq" $ }} ?
+	{{- else }} AND {{ $col }} {{ template "eq" $ }} ?
+	{{- end -}}
+{{- end -}}
+{{- end -}}
+{{- template "limit" . -}}
+{{- end -}}
+{{- define "eq" }}{{ if .WhereNullSafe }}<=>{{ else }}={{ end }}{{ end -}}
+{{- define "limit" }}{{ if gt .Limit 0 }} LIMIT {{ .Limit }}{{ end }}{{ end -}}
+`
+
+// onDuplicateKeySQL is the template for the ON DUPLICATE KEY UPDATE clause of
+// an upsert. Each of the UpdateColumns is set to the value of the row that
+// was to be INSERTed and each of the IncrementColumns is incremented by 1.
+var onDuplicateKeySQL = `{{ if or (gt (len .UpdateColumns) 0) (gt (len .IncrementColumns) 0) -}}
+{{ if ne .RowAlias "" }} AS {{ .RowAlias }}{{ end }} ON DUPLICATE KEY UPDATE
+{{- range $i, $col := .UpdateColumns -}}
+	{{- if eq $i 0 }} {{ else }}, {{ end -}}
+	{{- if ne $.RowAlias "" -}}
+		{{ $col }} = {{ $.RowAlias }}.{{ $col }}
+	{{- else -}}
+		{{ $col }} = VALUES({{ $col }})
 	{{- end -}}
 {{- end -}}
+{{- range $i, $col := .IncrementColumns -}}
+	{{- if and (eq $i 0) (eq (len $.UpdateColumns) 0) }} {{ else }}, {{ end -}}
+	{{ $col }} = {{ $col }} + 1
 {{- end -}}
 {{- end -}}
 `
 
 // selectAndOrWhereComment generates the example WHERE clause for the comments
-// of a SELECT range func.
+// of a SELECT range func. The WhereArgs, if any, are used for the arguments;
+// otherwise each argument is arg[i]. If WhereTuple is set, the arguments of
+// each comparison are the tuple's values. The WhereNull columns are included.
 var selectAndOrWhereComment = `{{ $ComparisonMinus := minusOne (len .WhereComparisonOps) -}}
-{{ if and (gt (len .WhereColumns) 0) (and (eq (len .WhereColumns) (len .WhereComparisonOps)) (and (eq (len .WhereConditions) $ComparisonMinus))) -}}
+{{ if .WhereTuple -}}
+{{ if and (gt (len .WhereColumns) 0) (and (gt (len .WhereComparisonOps) 0) (eq (len .WhereConditions) $ComparisonMinus)) -}}
+WHERE {{- range $i, $op := .WhereComparisonOps -}}
+	{{- if gt $i 0 }} {{ index $.WhereConditions (minusOne $i) }}{{ end }} {{ template "tuple" $.WhereColumns }} {{ $op }} ({{ argOf $ $i }})
+{{- end -}}
+{{- template "isnull" . -}}
+{{- end -}}
+{{- else if and (gt (len .WhereColumns) 0) (and (eq (len .WhereColumns) (len .WhereComparisonOps)) (and (eq (len .WhereConditions) $ComparisonMinus))) -}}
 WHERE {{- range $i, $col := .WhereColumns -}}
-	{{- if eq $i 0 }} {{ $col }} {{ index $.WhereComparisonOps $i }} arg[{{ $i }}]
-	{{- else }} {{index $.WhereConditions (minusOne ($i))}} {{ $col }} {{ index $.WhereComparisonOps $i }} arg[{{ $i }}]
+	{{- if eq $i 0 }} {{ $col }} {{ index $.WhereComparisonOps $i }} {{ argOf $ $i }}
+	{{- else }} {{index $.WhereConditions (minusOne ($i))}} {{ $col }} {{ index $.WhereComparisonOps $i }} {{ argOf $ $i }}
 	{{- end -}}
 {{- end -}}
+{{- template "isnull" . -}}
+{{- end -}}
+{{- define "tuple" -}}
+({{ range $i, $col := . }}{{ if gt $i 0 }}, {{ end }}{{ $col }}{{ end }})
+{{- end -}}
+{{- define "isnull" -}}
+{{ range $col := .WhereNull }} AND {{ $col }} IS NULL{{ end }}
 {{- end -}}
 `
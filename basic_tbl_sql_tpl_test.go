@@ -81,6 +81,26 @@ func TestTableSelectTemplate(t *testing.T) {
 			t.Errorf("%d: got %q want %q", i, buff.String(), expected[i])
 		}
 	}
+
+	// the WhereNull columns must be NULL, with or without WhereColumns.
+	for i, test := range []struct {
+		tbl  TableSQL
+		want string
+	}{
+		{TableSQL{Table: "foo", Columns: []string{"bar"}, WhereNull: []string{"deleted_at"}}, "SELECT bar FROM foo WHERE deleted_at IS NULL"},
+		{TableSQL{Table: "foo", Columns: []string{"bar"}, WhereColumns: []string{"id", "sid"}, WhereNull: []string{"deleted_at"}}, "SELECT bar FROM foo WHERE id = ? AND sid = ? AND deleted_at IS NULL"},
+		{TableSQL{Table: "foo", Columns: []string{"bar"}, WhereNull: []string{"deleted_at", "purged_at"}}, "SELECT bar FROM foo WHERE deleted_at IS NULL AND purged_at IS NULL"},
+	} {
+		buff.Reset()
+		err := SelectSQL.Execute(&buff, test.tbl)
+		if err != nil {
+			t.Errorf("null %d: %s", i, err)
+			continue
+		}
+		if buff.String() != test.want {
+			t.Errorf("null %d: got %q want %q", i, buff.String(), test.want)
+		}
+	}
 }
 
 func TestTableDELETETemplate(t *testing.T) {
@@ -111,6 +131,18 @@ func TestTableDELETETemplate(t *testing.T) {
 			t.Errorf("%d: got %q want %q", i, buff.String(), expected[i])
 		}
 	}
+
+	// the NULL-safe comparison of a row that doesn't have a key.
+	tbl := TableSQL{Table: "foo", WhereColumns: []string{"bar", "biz"}, WhereNullSafe: true, Limit: 1}
+	buff.Reset()
+	err := DeleteSQL.Execute(&buff, tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "DELETE FROM foo WHERE bar <=> ? AND biz <=> ? LIMIT 1"
+	if buff.String() != want {
+		t.Errorf("null-safe: got %q want %q", buff.String(), want)
+	}
 }
 
 func TestTableINSERTTemplate(t *testing.T) {
@@ -143,6 +175,55 @@ func TestTableINSERTTemplate(t *testing.T) {
 	}
 }
 
+func TestTableInsertNamedTemplates(t *testing.T) {
+	tbl := TableSQL{Table: "foo", Columns: []string{"bar", "biz", "baz"}}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"insertinto", "INSERT INTO foo (bar, biz, baz) VALUES"},
+		{"insertvalues", "(?, ?, ?)"},
+	}
+	var buff bytes.Buffer
+	for _, test := range tests {
+		buff.Reset()
+		err := InsertSQL.ExecuteTemplate(&buff, test.name, tbl)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if buff.String() != test.expected {
+			t.Errorf("%s: got %q want %q", test.name, buff.String(), test.expected)
+		}
+	}
+}
+
+func TestOnDuplicateKeyTemplate(t *testing.T) {
+	tests := []struct {
+		tbl      TableSQL
+		expected string
+	}{
+		{TableSQL{Table: "foo"}, ""},
+		{TableSQL{Table: "foo", UpdateColumns: []string{"bar"}}, " ON DUPLICATE KEY UPDATE bar = VALUES(bar)"},
+		{TableSQL{Table: "foo", UpdateColumns: []string{"bar", "biz"}}, " ON DUPLICATE KEY UPDATE bar = VALUES(bar), biz = VALUES(biz)"},
+		{TableSQL{Table: "foo", UpdateColumns: []string{"bar", "biz"}, RowAlias: "new"}, " AS new ON DUPLICATE KEY UPDATE bar = new.bar, biz = new.biz"},
+		{TableSQL{Table: "foo", UpdateColumns: []string{"bar"}, IncrementColumns: []string{"version"}}, " ON DUPLICATE KEY UPDATE bar = VALUES(bar), version = version + 1"},
+		{TableSQL{Table: "foo", IncrementColumns: []string{"version"}, RowAlias: "new"}, " AS new ON DUPLICATE KEY UPDATE version = version + 1"},
+	}
+	var buff bytes.Buffer
+	for i, test := range tests {
+		buff.Reset()
+		err := OnDuplicateKeySQL.Execute(&buff, test.tbl)
+		if err != nil {
+			t.Errorf("%d: %s", i, err)
+			continue
+		}
+		if buff.String() != test.expected {
+			t.Errorf("%d: got %q want %q", i, buff.String(), test.expected)
+		}
+	}
+}
+
 func TestTableUpdateTemplate(t *testing.T) {
 	expected := []string{
 		"",
@@ -171,6 +252,30 @@ func TestTableUpdateTemplate(t *testing.T) {
 			t.Errorf("%d: got %q want %q", i, buff.String(), expected[i])
 		}
 	}
+
+	// the IncrementColumns are incremented instead of being set.
+	tbl := TableSQL{Table: "foo", Columns: []string{"bar", "biz"}, WhereColumns: []string{"id", "version"}, IncrementColumns: []string{"version"}}
+	buff.Reset()
+	err := UpdateSQL.Execute(&buff, tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "UPDATE foo SET bar = ?, biz = ?, version = version + 1 WHERE id = ? AND version = ?"
+	if buff.String() != want {
+		t.Errorf("increment: got %q want %q", buff.String(), want)
+	}
+
+	// the NULL-safe comparison of a row that doesn't have a key.
+	tbl = TableSQL{Table: "foo", Columns: []string{"bar", "biz"}, WhereColumns: []string{"bar", "biz"}, WhereNullSafe: true, Limit: 1}
+	buff.Reset()
+	err = UpdateSQL.Execute(&buff, tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "UPDATE foo SET bar = ?, biz = ? WHERE bar <=> ? AND biz <=> ? LIMIT 1"
+	if buff.String() != want {
+		t.Errorf("null-safe: got %q want %q", buff.String(), want)
+	}
 }
 
 var andOrtests = []struct {
@@ -255,6 +360,80 @@ var andOrtests = []struct {
 		`SELECT id, val FROM abc WHERE id > ? AND id < ? AND val > ? AND val < ?`,
 		`WHERE id > arg[0] AND id < arg[1] AND val > arg[2] AND val < arg[3]`,
 	},
+	{
+		TableSQL{
+			Columns:            []string{"id", "val"},
+			Table:              "abc",
+			WhereColumns:       []string{"id", "val"},
+			WhereComparisonOps: []string{">", "<"},
+			WhereConditions:    []string{"AND"},
+			WhereTuple:         true,
+		},
+		`SELECT id, val FROM abc WHERE (id, val) > (?, ?) AND (id, val) < (?, ?)`,
+		`WHERE (id, val) > (arg[0]) AND (id, val) < (arg[1])`,
+	},
+	{
+		TableSQL{
+			Columns:            []string{"id", "val"},
+			Table:              "abc",
+			WhereColumns:       []string{"id", "val"},
+			WhereComparisonOps: []string{">=", "<="},
+			WhereConditions:    []string{"AND"},
+			WhereTuple:         true,
+			WhereArgs:          []string{"from.ID, from.Val", "to.ID, to.Val"},
+		},
+		`SELECT id, val FROM abc WHERE (id, val) >= (?, ?) AND (id, val) <= (?, ?)`,
+		`WHERE (id, val) >= (from.ID, from.Val) AND (id, val) <= (to.ID, to.Val)`,
+	},
+	{ // a tuple needs a condition for each pair of comparisons
+		TableSQL{
+			Columns:            []string{"id", "val"},
+			Table:              "abc",
+			WhereColumns:       []string{"id", "val"},
+			WhereComparisonOps: []string{">=", "<="},
+			WhereTuple:         true,
+		},
+		"",
+		"",
+	},
+	{
+		TableSQL{
+			Columns:            []string{"id", "val"},
+			Table:              "abc",
+			WhereColumns:       []string{"id", "id"},
+			WhereComparisonOps: []string{">", "<"},
+			WhereConditions:    []string{"AND"},
+			WhereArgs:          []string{"idFrom", "idTo"},
+		},
+		`SELECT id, val FROM abc WHERE id > ? AND id < ?`,
+		`WHERE id > idFrom AND id < idTo`,
+	},
+	{
+		TableSQL{
+			Columns:            []string{"id", "val"},
+			Table:              "abc",
+			WhereColumns:       []string{"id", "id"},
+			WhereComparisonOps: []string{">", "<"},
+			WhereConditions:    []string{"AND"},
+			WhereArgs:          []string{"idFrom", "idTo"},
+			WhereNull:          []string{"deleted_at"},
+		},
+		`SELECT id, val FROM abc WHERE id > ? AND id < ? AND deleted_at IS NULL`,
+		`WHERE id > idFrom AND id < idTo AND deleted_at IS NULL`,
+	},
+	{
+		TableSQL{
+			Columns:            []string{"id", "val"},
+			Table:              "abc",
+			WhereColumns:       []string{"id", "val"},
+			WhereComparisonOps: []string{">", "<"},
+			WhereConditions:    []string{"AND"},
+			WhereTuple:         true,
+			WhereNull:          []string{"deleted_at"},
+		},
+		`SELECT id, val FROM abc WHERE (id, val) > (?, ?) AND (id, val) < (?, ?) AND deleted_at IS NULL`,
+		`WHERE (id, val) > (arg[0]) AND (id, val) < (arg[1]) AND deleted_at IS NULL`,
+	},
 }
 
 func TestTableSelectANDORSQLTemplate(t *testing.T) {
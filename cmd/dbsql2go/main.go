@@ -16,6 +16,7 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
@@ -39,6 +40,27 @@ var (
 	password     string
 	out          string
 	filePerTable bool
+	nulls        string
+	textAsBytes  bool
+	uuidCols     string
+	uuidMarker   string
+	uuidSwap     bool
+	types        string
+	batchSize    int
+	upsertIgnore string
+	parentNames  string
+	rangeMode    string
+	childNames   string
+	versionCols  string
+	versionTS    bool
+	softDelete   string
+	createdCols  string
+	updatedCols  string
+	clock        string
+	insertResult bool
+	structTags   string
+	tagNames     string
+	jsonNulls    bool
 )
 
 func init() {
@@ -52,6 +74,27 @@ func init() {
 	flag.StringVar(&pkgName, "package", "", "name of the package of which the generated code is a part; if empty,the database name will be used")
 	flag.StringVar(&out, "out", "", "the output destination: if it doesn't end with a .go extension it will be assumed to be a path relative to the GOPATH/src dir. If empty, it will be the WD.")
 	flag.BoolVar(&filePerTable, "separatefiles", false, "use a file per table; each file will use the table's name")
+	flag.BoolVar(&textAsBytes, "textasbytes", false, "use []byte for character TEXT columns instead of string")
+	flag.StringVar(&uuidCols, "uuid", "", "comma separated list of patterns for the names of UUID columns, e.g. uuid,*_uuid,abc.id")
+	flag.StringVar(&uuidMarker, "uuidmarker", dbsql2go.DefaultUUIDMarker, "the column comment marker that identifies UUID columns")
+	flag.BoolVar(&uuidSwap, "uuidswap", false, "BINARY(16) UUIDs are stored using UUID_TO_BIN(x, 1)")
+	flag.StringVar(&types, "types", "", "comma separated list of column type overrides, e.g. abc.amount=github.com/shopspring/decimal.Decimal")
+	flag.IntVar(&batchSize, "batchsize", 0, "the maximum number of rows in each multi-row INSERT of the batch funcs; if 0, it is limited only by the maximum number of placeholders")
+	flag.StringVar(&upsertIgnore, "upsertignore", "", "comma separated list of the columns, as table.column, that upserts leave untouched when the row exists, e.g. abc.created")
+	flag.StringVar(&parentNames, "parentnames", "", "comma separated list of foreign key parent accessor names, as table.constraint=Name, e.g. order.fk_customer=Buyer")
+	flag.StringVar(&childNames, "childrennames", "", "comma separated list of foreign key children accessor names, as table.constraint=Name, e.g. order.fk_customer=Purchases")
+	flag.StringVar(&rangeMode, "rangemode", "box", "how the in range SELECTs compare composite primary keys: box (each column separately) or tuple (as a row constructor)")
+	flag.StringVar(&versionCols, "versioncolumns", "", "comma separated list of patterns for the names of the integer columns used for optimistic locking, e.g. version,abc.lock_version")
+	flag.BoolVar(&versionTS, "versiontimestamp", false, "use a NOT NULL column that is set ON UPDATE CURRENT_TIMESTAMP for optimistic locking when a table doesn't have a version column")
+	flag.StringVar(&softDelete, "softdelete", "", "comma separated list of patterns for the names of the NULL DATETIME or TIMESTAMP columns that mark rows as soft deleted, e.g. deleted_at")
+	flag.StringVar(&createdCols, "createdcolumns", "", "comma separated list of patterns for the names of the DATETIME or TIMESTAMP columns that are set to the current time by the INSERT, e.g. created_at")
+	flag.StringVar(&updatedCols, "updatedcolumns", "", "comma separated list of patterns for the names of the DATETIME or TIMESTAMP columns that are set to the current time by the INSERT and the UPDATE, e.g. updated_at")
+	flag.BoolVar(&insertResult, "insertresult", false, "make the Insert methods return the INSERT's sql.Result along with the error")
+	flag.StringVar(&structTags, "structtags", "", "comma separated list of the struct tags of the fields, as key[:naming][:omitempty], e.g. db,json:lowercamel:omitempty; naming is raw, snake, camel, or lowercamel")
+	flag.StringVar(&tagNames, "tagnames", "", "comma separated list of struct tag name overrides, as table.column[.key]=name, e.g. abc.code=key,abc.cost.json=-")
+	flag.BoolVar(&jsonNulls, "jsonnulls", false, "generate MarshalJSON and UnmarshalJSON methods that marshal NULL columns as null instead of as objects")
+	flag.StringVar(&clock, "clock", "", "the name of the Clock variable that the current time is gotten from; if empty, DefaultClock is used")
+	flag.StringVar(&nulls, "nulls", "sql", "how nullable columns are represented: sql (sql.Null* types), pointer, or generic (sql.Null[T])")
 
 	log.SetFlags(0)
 	log.SetPrefix(exe + ": ")
@@ -102,8 +145,69 @@ func main() {
 		log.Fatal("error: %s\n", err)
 	}
 
+	cfg := dbsql2go.Config{TextAsBytes: textAsBytes, UUIDMarker: uuidMarker, UUIDSwap: uuidSwap, BatchSize: batchSize, VersionTimestamp: versionTS, InsertResult: insertResult, JSONNulls: jsonNulls}
+	if uuidCols != "" {
+		cfg.UUIDColumns = strings.Split(uuidCols, ",")
+	}
+	if versionCols != "" {
+		cfg.VersionColumns = strings.Split(versionCols, ",")
+	}
+	if softDelete != "" {
+		cfg.SoftDeleteColumns = strings.Split(softDelete, ",")
+	}
+	if createdCols != "" {
+		cfg.CreatedColumns = strings.Split(createdCols, ",")
+	}
+	if updatedCols != "" {
+		cfg.UpdatedColumns = strings.Split(updatedCols, ",")
+	}
+	cfg.Clock = clock
+	if types != "" {
+		cfg.Types = map[string]string{}
+		for _, v := range strings.Split(types, ",") {
+			kv := strings.SplitN(v, "=", 2)
+			if len(kv) != 2 {
+				log.Fatalf("error: %q is not a valid type override: the format is table.column=type", v)
+			}
+			cfg.Types[kv[0]] = kv[1]
+		}
+	}
+	if upsertIgnore != "" {
+		cfg.UpsertIgnore = map[string][]string{}
+		for _, v := range strings.Split(upsertIgnore, ",") {
+			tc := strings.SplitN(v, ".", 2)
+			if len(tc) != 2 {
+				log.Fatalf("error: %q is not a valid upsert ignore column: the format is table.column", v)
+			}
+			cfg.UpsertIgnore[tc[0]] = append(cfg.UpsertIgnore[tc[0]], tc[1])
+		}
+	}
+	cfg.Tags, err = tags(structTags)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+	if tagNames != "" {
+		cfg.TagNames = map[string]string{}
+		for _, v := range strings.Split(tagNames, ",") {
+			kv := strings.SplitN(v, "=", 2)
+			if len(kv) != 2 || !strings.Contains(kv[0], ".") {
+				log.Fatalf("error: %q is not a valid tag name: the format is table.column[.key]=name", v)
+			}
+			cfg.TagNames[kv[0]] = kv[1]
+		}
+	}
+	cfg.ParentNames = accessorNames(parentNames)
+	cfg.ChildrenNames = accessorNames(childNames)
+	cfg.NullStrategy, err = dbsql2go.ParseNullStrategy(nulls)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+	cfg.RangeMode, err = dbsql2go.ParseRangeMode(rangeMode)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
 	var DB dbsql2go.DBer
-	var imp string // the db specific driver
 
 	// Connect to the DB
 	switch typ {
@@ -112,8 +216,9 @@ func main() {
 		if err != nil {
 			log.Fatal("error: %s connect: %s\n", typ, err)
 		}
-		imp = mysql.Import()
 	}
+	DB.SetConfig(cfg)
+	imports := dbsql2go.NewImports(&cfg)
 
 	// Get gets all of the information for the specified db.
 	err = DB.Get()
@@ -138,42 +243,56 @@ func main() {
 		log.Fatal("error: package comment: %s\n", err)
 	}
 
+	// The imports depend on the generated code so each file's code is
+	// generated before anything else is written to the file.
+	var buf bytes.Buffer
+	err = DB.Shared(&buf)
+	if err != nil {
+		w.(*os.File).Close()
+		log.Fatalf("error: generating shared code: %s", err)
+	}
 	if filePerTable {
+		// the db file only has the shared code.
+		err = writeFile(w, imports, &buf)
 		w.(*os.File).Close() // close the db file; the table specific ones will be written to their own.
-	} else {
-		_, err = writeTableFileComments(w, imp)
 		if err != nil {
-			w.(*os.File).Close()
-			log.Fatal("error: package statements: %s\n", err)
+			log.Fatalf("error: %s", err)
 		}
 	}
 
 	// Get all the tables; this also gathers all relevant db info.
 	tables := DB.Tables()
 	// dump all the Go table definitions to file
-	// TODO: add support for file per table
 	for _, tbl := range tables {
-		if filePerTable {
-			// open the file for this table
-			w, err = os.OpenFile(filepath.Join(out, tbl.Name()+".go"), os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0766)
-			if err != nil {
-				log.Fatal("error: open file: %s\n", err)
-			}
-
-		}
-		_, err = w.Write([]byte("\n\n"))
+		_, err = buf.WriteString("\n\n")
 		if err != nil {
 			log.Fatal("error: writing table separator lines: %s\n", err)
-			w.(*os.File).Close()
 		}
 
-		err := tbl.GoFmt(w)
+		err := tbl.GoFmt(&buf)
 		if err != nil {
-			w.(*os.File).Close()
 			log.Fatal("error: generating Go struct definition for %s.%s: %s\n", dbName, tbl.Name(), err)
 		}
-		if filePerTable { // done writing to the file so close it.
-			w.(*os.File).Close()
+		if !filePerTable {
+			continue
+		}
+		// open the file for this table
+		w, err = os.OpenFile(filepath.Join(out, tbl.Name()+".go"), os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0766)
+		if err != nil {
+			log.Fatal("error: open file: %s\n", err)
+		}
+		err = writeFile(w, imports, &buf)
+		w.(*os.File).Close() // done writing to the file so close it.
+		if err != nil {
+			log.Fatalf("error: %s: %s", tbl.Name(), err)
+		}
+	}
+
+	if !filePerTable {
+		err = writeFile(w, imports, &buf)
+		w.(*os.File).Close()
+		if err != nil {
+			log.Fatalf("error: %s", err)
 		}
 	}
 
@@ -258,6 +377,69 @@ output:
 	return w, filename, nil
 }
 
-func writeTableFileComments(w io.Writer, imp string) (n int, err error) {
-	return w.Write([]byte(fmt.Sprintf("package %s\n\nimport (\n\t\"database/sql\"\n\n\t%s\n)\n", pkgName, imp)))
+// writeTableFileComments writes the package clause followed by the imports.
+func writeTableFileComments(w io.Writer, imports []byte) (n int, err error) {
+	return w.Write([]byte(fmt.Sprintf("package %s\n\n%s", pkgName, imports)))
+}
+
+// writeFile writes the package clause, the imports used by the generated code
+// in buf, and the generated code to w. buf is reset.
+func writeFile(w io.Writer, imports *dbsql2go.Imports, buf *bytes.Buffer) error {
+	defer buf.Reset()
+	decl, err := imports.Decl(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = writeTableFileComments(w, decl)
+	if err != nil {
+		return fmt.Errorf("package statements: %s", err)
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// tags parses a comma separated list of struct tags, in the form
+// key[:naming][:omitempty].
+func tags(s string) ([]dbsql2go.Tag, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var tags []dbsql2go.Tag
+	for _, v := range strings.Split(s, ",") {
+		parts := strings.Split(v, ":")
+		tag := dbsql2go.Tag{Key: parts[0]}
+		for _, p := range parts[1:] {
+			if p == "omitempty" {
+				tag.OmitEmpty = true
+				continue
+			}
+			var err error
+			tag.Naming, err = dbsql2go.ParseTagNaming(p)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if tag.Key == "" {
+			return nil, fmt.Errorf("%q is not a valid struct tag: the format is key[:naming][:omitempty]", v)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// accessorNames parses a comma separated list of foreign key accessor names,
+// in the form table.constraint=Name.
+func accessorNames(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	names := map[string]string{}
+	for _, v := range strings.Split(s, ",") {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || !strings.Contains(kv[0], ".") {
+			log.Fatalf("error: %q is not a valid accessor name: the format is table.constraint=Name", v)
+		}
+		names[kv[0]] = kv[1]
+	}
+	return names
 }
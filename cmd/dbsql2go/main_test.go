@@ -4,7 +4,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/mohae/dbsql2go"
 )
 
 func TestSetOutput(t *testing.T) {
@@ -72,3 +75,20 @@ func TestSetOutput(t *testing.T) {
 		}
 	}
 }
+
+func TestTags(t *testing.T) {
+	got, err := tags("db,json:lowercamel:omitempty,yaml:snake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dbsql2go.Tag{{Key: "db"}, {Key: "json", Naming: dbsql2go.TagLowerCamel, OmitEmpty: true}, {Key: "yaml", Naming: dbsql2go.TagSnake}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	for _, v := range []string{"json:kebab", ":snake"} {
+		_, err = tags(v)
+		if err == nil {
+			t.Errorf("%s: got no error; want an error", v)
+		}
+	}
+}
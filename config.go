@@ -0,0 +1,407 @@
+// Copyright 2016-17 Joel Scoble.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbsql2go
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Config holds the options that control the generation of Go code. The zero
+// value of each option results in the default behavior, so a zero Config can
+// be used as is.
+type Config struct {
+	NullStrategy NullStrategy // how nullable columns are represented in Go.
+	// TextAsBytes uses []byte for character TEXT columns instead of string.
+	TextAsBytes bool
+	// UUIDColumns are path.Match patterns for the names of columns that hold
+	// UUIDs. A pattern may be qualified with a table name, e.g. "abc.*_uuid".
+	UUIDColumns []string
+	// UUIDMarker identifies UUID columns by their comment: any column whose
+	// comment contains the marker holds UUIDs. If empty, DefaultUUIDMarker is
+	// used.
+	UUIDMarker string
+	// UUIDSwap is used when BINARY(16) UUIDs are stored using MySQL's
+	// UUID_TO_BIN(x, 1).
+	UUIDSwap bool
+	// Types overrides the Go type of specific columns. The keys are column
+	// names qualified by their table's name, e.g. "abc.amount". Types from
+	// other packages are specified using the package's import path, e.g.
+	// "github.com/shopspring/decimal.Decimal"; the import is added to the
+	// generated code.
+	Types map[string]string
+	// BatchSize is the maximum number of rows in each of the multi-row
+	// INSERTs done by the batch funcs. If 0, the number of rows is limited
+	// only by the maximum number of placeholders in a statement.
+	BatchSize int
+	// UpsertIgnore are the columns, by table name, that an upsert leaves
+	// untouched when the row already exists, e.g. {"abc": {"created"}}.
+	UpsertIgnore map[string][]string
+	// ParentNames and ChildrenNames override the names of the foreign key
+	// accessors: the parent accessor on the referencing struct and the
+	// children accessor on the referenced struct. The keys are foreign key
+	// names qualified by their table's name, e.g. "order.fk_customer".
+	ParentNames   map[string]string
+	ChildrenNames map[string]string
+	// RangeMode is how the in range SELECTs compare composite primary keys.
+	RangeMode RangeMode
+	// VersionColumns are path.Match patterns for the names of the columns
+	// used for optimistic locking, e.g. "version" or "abc.lock_version". Only
+	// NOT NULL integer columns are used.
+	VersionColumns []string
+	// VersionTimestamp uses a NOT NULL column that is set ON UPDATE
+	// CURRENT_TIMESTAMP for optimistic locking when a table doesn't have a
+	// column that matches the VersionColumns.
+	VersionTimestamp bool
+	// SoftDeleteColumns are path.Match patterns for the names of the columns
+	// that mark a row as soft deleted, e.g. "deleted_at". Only NULL DATETIME
+	// and TIMESTAMP columns are used: a row whose column isn't NULL has been
+	// deleted.
+	SoftDeleteColumns []string
+	// CreatedColumns are path.Match patterns for the names of the DATETIME
+	// and TIMESTAMP columns that hold the time that a row was INSERTed, e.g.
+	// "created_at". The generated code sets them unless the server does, i.e.
+	// the column has a default or is set ON UPDATE.
+	CreatedColumns []string
+	// UpdatedColumns are path.Match patterns for the names of the DATETIME
+	// and TIMESTAMP columns that hold the time that a row was last INSERTed or
+	// UPDATEd, e.g. "updated_at". The generated code sets them unless the
+	// server does, i.e. the column has a default or is set ON UPDATE.
+	UpdatedColumns []string
+	// Clock is the name of the variable, of the generated Clock type, that
+	// the generated code gets the current time from; if empty, DefaultClock
+	// is used.
+	Clock string
+	// InsertResult makes the generated Insert methods return the INSERT's
+	// sql.Result along with the error instead of just the error. Either way,
+	// the struct's auto-increment field is set to the generated ID.
+	InsertResult bool
+	// Tags are the struct tags of the generated struct fields, in order, e.g.
+	// the db tag for sqlx and the json tag. If empty, the fields don't have
+	// tags.
+	Tags []Tag
+	// TagNames override the names, in the struct tags, of specific columns.
+	// The keys are column names qualified by their table's name, e.g.
+	// "abc.code", which overrides the name in all of the tags, or also by a
+	// tag's key, e.g. "abc.code.json", which overrides it in just that tag. A
+	// name of "-" leaves the field out, e.g. of the JSON.
+	TagNames map[string]string
+	// JSONNulls generates MarshalJSON and UnmarshalJSON methods for the
+	// structs with NULL wrapper fields, e.g. sql.NullString, so that the
+	// columns are marshaled as plain values, and NULLs as null, instead of as
+	// objects, e.g. {"String":"x","Valid":true}. The names in the JSON are
+	// those of the json Tag, if there is one.
+	JSONNulls bool
+
+	imports *Imports // resolves the Types; created by the first ColumnType
+}
+
+// Tag is a struct tag key of the generated struct fields along with how the
+// names in it are derived from the column names.
+type Tag struct {
+	Key    string    // the tag's key, e.g. "db" or "json"
+	Naming TagNaming // how a column's name is converted to the name in the tag
+	// OmitEmpty adds the omitempty option to the tags of nullable columns.
+	OmitEmpty bool
+}
+
+// StructTag returns the struct tag, including its back quotes, of the
+// column's field, using the Tags and the TagNames. If there aren't any Tags,
+// an empty string is returned.
+func (c *Config) StructTag(table, column string, nullable bool) string {
+	if len(c.Tags) == 0 {
+		return ""
+	}
+	tags := make([]string, len(c.Tags))
+	for i, tag := range c.Tags {
+		name, ok := c.TagNames[table+"."+column+"."+tag.Key]
+		if !ok {
+			name, ok = c.TagNames[table+"."+column]
+		}
+		if !ok {
+			name = tag.Naming.Name(column)
+		}
+		if tag.OmitEmpty && nullable && name != "-" {
+			name += ",omitempty"
+		}
+		tags[i] = tag.Key + ":" + strconv.Quote(name)
+	}
+	return "`" + strings.Join(tags, " ") + "`"
+}
+
+// ColumnType returns the Go type, as used in the generated code, that
+// overrides the column's type, if there is one. The Imports that resolve the
+// types are only created once, so the Types mustn't be changed after the
+// first call.
+func (c *Config) ColumnType(table, column string) (typ string, ok bool) {
+	typ, ok = c.Types[table+"."+column]
+	if !ok {
+		return "", false
+	}
+	if c.imports == nil {
+		c.imports = NewImports(c)
+	}
+	return c.imports.Type(typ), true
+}
+
+// DefaultUUIDMarker is the column comment marker used to identify UUID
+// columns when Config.UUIDMarker is not set.
+const DefaultUUIDMarker = "dbsql2go:uuid"
+
+// IsUUIDColumn returns whether or not the column holds UUIDs, either because
+// the column's name, optionally qualified by its table's name, matches one of
+// the UUIDColumns patterns or because its comment contains the UUIDMarker.
+func (c *Config) IsUUIDColumn(table, column, comment string) bool {
+	if matchColumn(c.UUIDColumns, table, column) {
+		return true
+	}
+	marker := c.UUIDMarker
+	if marker == "" {
+		marker = DefaultUUIDMarker
+	}
+	return strings.Contains(comment, marker)
+}
+
+// IsVersionColumn returns whether or not the column's name, optionally
+// qualified by its table's name, matches one of the VersionColumns patterns.
+func (c *Config) IsVersionColumn(table, column string) bool {
+	return matchColumn(c.VersionColumns, table, column)
+}
+
+// IsSoftDeleteColumn returns whether or not the column's name, optionally
+// qualified by its table's name, matches one of the SoftDeleteColumns
+// patterns.
+func (c *Config) IsSoftDeleteColumn(table, column string) bool {
+	return matchColumn(c.SoftDeleteColumns, table, column)
+}
+
+// IsCreatedColumn returns whether or not the column's name, optionally
+// qualified by its table's name, matches one of the CreatedColumns patterns.
+func (c *Config) IsCreatedColumn(table, column string) bool {
+	return matchColumn(c.CreatedColumns, table, column)
+}
+
+// IsUpdatedColumn returns whether or not the column's name, optionally
+// qualified by its table's name, matches one of the UpdatedColumns patterns.
+func (c *Config) IsUpdatedColumn(table, column string) bool {
+	return matchColumn(c.UpdatedColumns, table, column)
+}
+
+// matchColumn returns whether or not the column's name matches one of the
+// patterns. Patterns that contain a "." are matched against the column's name
+// qualified by its table's name.
+func matchColumn(patterns []string, table, column string) bool {
+	for _, pattern := range patterns {
+		name := column
+		if strings.Contains(pattern, ".") {
+			name = table + "." + column
+		}
+		ok, _ := path.Match(pattern, name)
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	NullSQL     NullStrategy = iota // sql.Null* types, e.g. sql.NullInt64; the default
+	NullPointer                     // pointers to the column's Go type, e.g. *int8
+	NullGeneric                     // Go 1.22's sql.Null[T] using the column's Go type, e.g. sql.Null[int8]
+)
+
+// NullStrategy is how nullable columns are represented in the generated code.
+// With NullSQL, all of the time columns, including the NOT NULL ones, are
+// mysql.NullTime, which doesn't require the driver's parseTime option. The
+// other strategies use time.Time, so the DSN must set parseTime=true.
+//
+//go:generate stringer -type=NullStrategy
+type NullStrategy int
+
+// ParseNullStrategy returns the NullStrategy that corresponds to s.
+func ParseNullStrategy(s string) (NullStrategy, error) {
+	v := strings.ToLower(s)
+	switch v {
+	case "", "sql", "nullsql":
+		return NullSQL, nil
+	case "pointer", "ptr", "nullpointer":
+		return NullPointer, nil
+	case "generic", "nullgeneric":
+		return NullGeneric, nil
+	default:
+		return NullSQL, UnknownNullStrategyErr{s}
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so that a NullStrategy
+// can be set by name in a config file.
+func (n *NullStrategy) UnmarshalText(b []byte) error {
+	v, err := ParseNullStrategy(string(b))
+	if err != nil {
+		return err
+	}
+	*n = v
+	return nil
+}
+
+const (
+	RangeBox   RangeMode = iota // each of the key's columns is within its own range; the default
+	RangeTuple                  // the key, compared as a row constructor, is within the range
+)
+
+// RangeMode is how the in range SELECTs compare composite primary keys.
+// RangeBox compares each column separately, e.g. a > ? AND a < ? AND b > ?
+// AND b < ?, which selects a box of keys. RangeTuple compares the keys as a
+// whole, e.g. (a, b) > (?, ?) AND (a, b) < (?, ?), which selects the keys
+// that are between the bounds in key order.
+//
+//go:generate stringer -type=RangeMode
+type RangeMode int
+
+// ParseRangeMode returns the RangeMode that corresponds to s.
+func ParseRangeMode(s string) (RangeMode, error) {
+	switch strings.ToLower(s) {
+	case "", "box", "rangebox":
+		return RangeBox, nil
+	case "tuple", "rangetuple":
+		return RangeTuple, nil
+	default:
+		return RangeBox, UnknownRangeModeErr{s}
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so that a RangeMode can
+// be set by name in a config file.
+func (r *RangeMode) UnmarshalText(b []byte) error {
+	v, err := ParseRangeMode(string(b))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+const (
+	TagRaw        TagNaming = iota // the column's name as is, e.g. "def_id" or "defID"; the default
+	TagSnake                       // snake_case, e.g. "def_id"
+	TagCamel                       // CamelCase, e.g. "DefId"
+	TagLowerCamel                  // lowerCamelCase, e.g. "defId"
+)
+
+// TagNaming is how a column's name is converted to its name in a struct tag.
+// The words of the name are separated by anything that isn't a letter or a
+// digit and by a change from a lower case letter, or a digit, to an upper case
+// letter, e.g. both "def_id" and "defID" are "def" and "id".
+//
+//go:generate stringer -type=TagNaming
+type TagNaming int
+
+// ParseTagNaming returns the TagNaming that corresponds to s.
+func ParseTagNaming(s string) (TagNaming, error) {
+	switch strings.ToLower(s) {
+	case "", "raw", "tagraw":
+		return TagRaw, nil
+	case "snake", "tagsnake":
+		return TagSnake, nil
+	case "camel", "tagcamel":
+		return TagCamel, nil
+	case "lowercamel", "taglowercamel":
+		return TagLowerCamel, nil
+	default:
+		return TagRaw, UnknownTagNamingErr{s}
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so that a TagNaming can
+// be set by name in a config file.
+func (n *TagNaming) UnmarshalText(b []byte) error {
+	v, err := ParseTagNaming(string(b))
+	if err != nil {
+		return err
+	}
+	*n = v
+	return nil
+}
+
+// Name returns the column's name converted using the naming.
+func (n TagNaming) Name(column string) string {
+	if n == TagRaw {
+		return column
+	}
+	ws := words(column)
+	for i, w := range ws {
+		if n == TagSnake || n == TagLowerCamel && i == 0 {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(w)
+		ws[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+	if n == TagSnake {
+		return strings.Join(ws, "_")
+	}
+	return strings.Join(ws, "")
+}
+
+// words returns the lower case words of a name; see TagNaming.
+func words(s string) []string {
+	var words []string
+	var word []rune
+	var prior rune
+	for _, r := range s {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = word[:0]
+		case unicode.IsUpper(r) && (unicode.IsLower(prior) || unicode.IsDigit(prior)) && len(word) > 0:
+			words = append(words, string(word))
+			word = append(word[:0], unicode.ToLower(r))
+		default:
+			word = append(word, unicode.ToLower(r))
+		}
+		prior = r
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+type UnknownTagNamingErr struct {
+	Value string
+}
+
+func (u UnknownTagNamingErr) Error() string {
+	return u.Value + " is not a known tag naming"
+}
+
+type UnknownRangeModeErr struct {
+	Value string
+}
+
+func (u UnknownRangeModeErr) Error() string {
+	return u.Value + " is not a known range mode"
+}
+
+type UnknownNullStrategyErr struct {
+	Value string
+}
+
+func (u UnknownNullStrategyErr) Error() string {
+	return u.Value + " is not a known null strategy"
+}
@@ -101,6 +101,8 @@ type DBer interface {
 	Views() []Viewer
 	UpdateTableConstraints() error
 	UpdateTableIndexes()
+	SetConfig(Config)      // Set the options used for code generation.
+	Shared(io.Writer) error // Write the code that is shared by the tables.
 }
 
 // Tabler
@@ -150,6 +152,16 @@ type Constraint struct {
 	RefFields  []string       // the Go struct field names corresponding to the table's column names.
 }
 
+// HasColumn returns whether or not the column is part of the constraint.
+func (c *Constraint) HasColumn(name string) bool {
+	for _, v := range c.Columns {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Viewer
 type Viewer interface {
 	Name() string // Just so that there's semething to fulfill until this gets fleshed out further.
@@ -83,6 +83,140 @@ func TestParseConstraintType(t *testing.T) {
 	}
 }
 
+func TestParseNullStrategy(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected NullStrategy
+		err      error
+	}{
+		{"", NullSQL, nil},
+		{"sql", NullSQL, nil},
+		{"SQL", NullSQL, nil},
+		{"pointer", NullPointer, nil},
+		{"Ptr", NullPointer, nil},
+		{"generic", NullGeneric, nil},
+		{"GENERIC", NullGeneric, nil},
+		{"ref", NullSQL, UnknownNullStrategyErr{"ref"}},
+	}
+
+	for _, test := range tests {
+		n, err := ParseNullStrategy(test.value)
+		if err != test.err {
+			t.Errorf("%s: got %v want %v", test.value, err, test.err)
+			continue
+		}
+		if n != test.expected {
+			t.Errorf("%s: got %v want %v", test.value, n, test.expected)
+		}
+	}
+}
+
+func TestParseRangeMode(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected RangeMode
+		err      error
+	}{
+		{"", RangeBox, nil},
+		{"box", RangeBox, nil},
+		{"Tuple", RangeTuple, nil},
+		{"rangetuple", RangeTuple, nil},
+		{"row", RangeBox, UnknownRangeModeErr{"row"}},
+	}
+
+	for _, test := range tests {
+		r, err := ParseRangeMode(test.value)
+		if err != test.err {
+			t.Errorf("%s: got %v want %v", test.value, err, test.err)
+			continue
+		}
+		if r != test.expected {
+			t.Errorf("%s: got %v want %v", test.value, r, test.expected)
+		}
+	}
+}
+
+func TestParseTagNaming(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected TagNaming
+		err      error
+	}{
+		{"", TagRaw, nil},
+		{"raw", TagRaw, nil},
+		{"Snake", TagSnake, nil},
+		{"camel", TagCamel, nil},
+		{"lowerCamel", TagLowerCamel, nil},
+		{"kebab", TagRaw, UnknownTagNamingErr{"kebab"}},
+	}
+
+	for _, test := range tests {
+		n, err := ParseTagNaming(test.value)
+		if err != test.err {
+			t.Errorf("%s: got %v want %v", test.value, err, test.err)
+			continue
+		}
+		if n != test.expected {
+			t.Errorf("%s: got %v want %v", test.value, n, test.expected)
+		}
+	}
+}
+
+func TestTagNamingName(t *testing.T) {
+	tests := []struct {
+		column     string
+		raw        string
+		snake      string
+		camel      string
+		lowerCamel string
+	}{
+		{"id", "id", "id", "Id", "id"},
+		{"def_id", "def_id", "def_id", "DefId", "defId"},
+		{"defID", "defID", "def_id", "DefId", "defId"},
+		{"Long Stuff", "Long Stuff", "long_stuff", "LongStuff", "longStuff"},
+		{"utf8Name", "utf8Name", "utf8_name", "Utf8Name", "utf8Name"},
+		{"élan_vital", "élan_vital", "élan_vital", "ÉlanVital", "élanVital"},
+		{"prix_Été", "prix_Été", "prix_été", "PrixÉté", "prixÉté"},
+	}
+	for _, test := range tests {
+		for i, want := range []string{test.raw, test.snake, test.camel, test.lowerCamel} {
+			got := TagNaming(i).Name(test.column)
+			if got != want {
+				t.Errorf("%s: %s: got %q want %q", test.column, TagNaming(i), got, want)
+			}
+		}
+	}
+}
+
+func TestStructTag(t *testing.T) {
+	cfg := Config{
+		Tags:     []Tag{{Key: "db"}, {Key: "json", Naming: TagLowerCamel, OmitEmpty: true}},
+		TagNames: map[string]string{"abc.code": "key", "abc.cost.json": "-"},
+	}
+	tests := []struct {
+		table    string
+		column   string
+		nullable bool
+		expected string
+	}{
+		{"abc", "def_id", false, "`db:\"def_id\" json:\"defId\"`"},
+		{"abc", "def_id", true, "`db:\"def_id\" json:\"defId,omitempty\"`"},
+		{"abc", "code", false, "`db:\"key\" json:\"key\"`"},
+		{"abc", "cost", true, "`db:\"cost\" json:\"-\"`"},
+		{"def", "cost", true, "`db:\"cost\" json:\"cost,omitempty\"`"},
+	}
+	for _, test := range tests {
+		got := cfg.StructTag(test.table, test.column, test.nullable)
+		if got != test.expected {
+			t.Errorf("%s.%s: got %s want %s", test.table, test.column, got, test.expected)
+		}
+	}
+	cfg.Tags = nil
+	if got := cfg.StructTag("abc", "code", false); got != "" {
+		t.Errorf("no tags: got %s want an empty string", got)
+	}
+}
+
 func TestStringInComments(t *testing.T) {
 	tests := []struct {
 		line    string
@@ -109,3 +243,100 @@ func TestStringInComments(t *testing.T) {
 		}
 	}
 }
+
+func TestIsUUIDColumn(t *testing.T) {
+	tests := []struct {
+		cfg      Config
+		table    string
+		column   string
+		comment  string
+		expected bool
+	}{
+		{Config{}, "abc", "id", "", false},
+		{Config{}, "abc", "id", "the row's dbsql2go:uuid", true},
+		{Config{UUIDMarker: "[uuid]"}, "abc", "id", "dbsql2go:uuid", false},
+		{Config{UUIDMarker: "[uuid]"}, "abc", "id", "[uuid] the row's id", true},
+		{Config{UUIDColumns: []string{"uuid", "*_uuid"}}, "abc", "uuid", "", true},
+		{Config{UUIDColumns: []string{"uuid", "*_uuid"}}, "abc", "ref_uuid", "", true},
+		{Config{UUIDColumns: []string{"uuid", "*_uuid"}}, "abc", "uuids", "", false},
+		{Config{UUIDColumns: []string{"abc.id"}}, "abc", "id", "", true},
+		{Config{UUIDColumns: []string{"abc.id"}}, "def", "id", "", false},
+	}
+	for i, test := range tests {
+		b := test.cfg.IsUUIDColumn(test.table, test.column, test.comment)
+		if b != test.expected {
+			t.Errorf("%d: %s.%s: got %t want %t", i, test.table, test.column, b, test.expected)
+		}
+	}
+}
+
+func TestIsVersionColumn(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		table    string
+		column   string
+		expected bool
+	}{
+		{nil, "abc", "version", false},
+		{[]string{"version", "lock_version"}, "abc", "version", true},
+		{[]string{"version", "lock_version"}, "abc", "lock_version", true},
+		{[]string{"version", "lock_version"}, "abc", "versions", false},
+		{[]string{"abc.rev"}, "abc", "rev", true},
+		{[]string{"abc.rev"}, "def", "rev", false},
+	}
+	for i, test := range tests {
+		cfg := Config{VersionColumns: test.patterns}
+		b := cfg.IsVersionColumn(test.table, test.column)
+		if b != test.expected {
+			t.Errorf("%d: %s.%s: got %t want %t", i, test.table, test.column, b, test.expected)
+		}
+	}
+}
+
+func TestIsSoftDeleteColumn(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		table    string
+		column   string
+		expected bool
+	}{
+		{nil, "abc", "deleted_at", false},
+		{[]string{"deleted_at"}, "abc", "deleted_at", true},
+		{[]string{"*_deleted"}, "abc", "when_deleted", true},
+		{[]string{"abc.removed"}, "abc", "removed", true},
+		{[]string{"abc.removed"}, "def", "removed", false},
+	}
+	for i, test := range tests {
+		cfg := Config{SoftDeleteColumns: test.patterns}
+		b := cfg.IsSoftDeleteColumn(test.table, test.column)
+		if b != test.expected {
+			t.Errorf("%d: %s.%s: got %t want %t", i, test.table, test.column, b, test.expected)
+		}
+	}
+}
+
+func TestIsTimestampColumn(t *testing.T) {
+	cfg := Config{CreatedColumns: []string{"created_at", "abc.made"}, UpdatedColumns: []string{"updated_*"}}
+	tests := []struct {
+		table   string
+		column  string
+		created bool
+		updated bool
+	}{
+		{"abc", "created_at", true, false},
+		{"abc", "made", true, false},
+		{"def", "made", false, false},
+		{"abc", "updated_at", false, true},
+		{"abc", "updated", false, false},
+	}
+	for i, test := range tests {
+		b := cfg.IsCreatedColumn(test.table, test.column)
+		if b != test.created {
+			t.Errorf("%d: %s.%s: created: got %t want %t", i, test.table, test.column, b, test.created)
+		}
+		b = cfg.IsUpdatedColumn(test.table, test.column)
+		if b != test.updated {
+			t.Errorf("%d: %s.%s: updated: got %t want %t", i, test.table, test.column, b, test.updated)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2016-17 Joel Scoble.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbsql2go
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenImports are the import paths of the packages that generated code may
+// reference. They are always referred to by their package name.
+var GenImports = []string{
+	"context",
+	"database/sql",
+	"encoding/json",
+	"errors",
+	"strconv",
+	"strings",
+	"time",
+	"github.com/go-sql-driver/mysql",
+	"github.com/mohae/dbsql2go/uuid",
+}
+
+// Imports keeps track of the packages that generated code can reference and
+// the name used to refer to each of them. Packages whose names collide with
+// a package that has already been added are given an alias.
+type Imports struct {
+	names map[string]string // import path -> name used in the generated code
+	paths map[string]string // name used in the generated code -> import path
+}
+
+// NewImports returns the Imports for code generated using cfg. The GenImports
+// are added first followed by the packages of cfg's Types, in sorted order,
+// so that the same Config always results in the same names.
+func NewImports(cfg *Config) *Imports {
+	i := &Imports{names: map[string]string{}, paths: map[string]string{}}
+	for _, v := range GenImports {
+		i.Add(v)
+	}
+	var paths []string
+	for _, v := range cfg.Types {
+		p, _ := splitType(v)
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	for _, v := range paths {
+		i.Add(v)
+	}
+	return i
+}
+
+// Add adds the package with the import path p, if it hasn't already been
+// added, and returns the name that the generated code uses for it.
+func (i *Imports) Add(p string) string {
+	if name, ok := i.names[p]; ok {
+		return name
+	}
+	name := pkgName(p)
+	if _, ok := i.paths[name]; ok {
+		// use the parent directory as a prefix; if that collides too, number it.
+		alias := pkgName(path.Dir(p)) + name
+		for n := 2; ; n++ {
+			if _, ok := i.paths[alias]; !ok {
+				break
+			}
+			alias = fmt.Sprintf("%s%d", name, n)
+		}
+		name = alias
+	}
+	i.names[p] = name
+	i.paths[name] = p
+	return name
+}
+
+// Decl returns the import declaration for the packages referenced by src,
+// which is Go source without the package clause. Standard library packages
+// are grouped before all other packages. If src doesn't reference any of the
+// packages, an empty slice is returned.
+func (i *Imports) Decl(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", append([]byte("package p\n"), src...), 0)
+	if err != nil {
+		return nil, fmt.Errorf("imports: %s", err)
+	}
+	used := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		// package qualifiers are the only unresolved identifiers that are selected from.
+		id, ok := sel.X.(*ast.Ident)
+		if ok && id.Obj == nil {
+			if p, ok := i.paths[id.Name]; ok {
+				used[p] = true
+			}
+		}
+		return true
+	})
+	if len(used) == 0 {
+		return nil, nil
+	}
+	var std, other []string
+	for p := range used {
+		if strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+			other = append(other, p)
+			continue
+		}
+		std = append(std, p)
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	var buf bytes.Buffer
+	buf.WriteString("import (\n")
+	for j, group := range [][]string{std, other} {
+		if j > 0 && len(std) > 0 && len(other) > 0 {
+			buf.WriteByte(LF)
+		}
+		for _, p := range group {
+			buf.WriteByte('\t')
+			if i.names[p] != path.Base(p) {
+				buf.WriteString(i.names[p] + " ")
+			}
+			buf.WriteString(strconv.Quote(p))
+			buf.WriteByte(LF)
+		}
+	}
+	buf.WriteString(")\n")
+	return buf.Bytes(), nil
+}
+
+// Type returns the type, as used in the generated code, for a type that is
+// specified using its package's import path, e.g. "github.com/shopspring/
+// decimal.Decimal" becomes "decimal.Decimal". The type may be prefixed with
+// "*" or "[]". If the type doesn't have an import path, e.g. "int64", it is
+// returned as is.
+func (i *Imports) Type(typ string) string {
+	p, name := splitType(typ)
+	if p == "" {
+		return typ
+	}
+	prefix := typ[:len(typ)-len(strings.TrimLeft(typ, "*[]"))]
+	return prefix + i.Add(p) + "." + name
+}
+
+// splitType splits a type specified using its package's import path into the
+// import path and the type name. If the type doesn't have an import path, the
+// returned path is empty.
+func splitType(typ string) (p, name string) {
+	typ = strings.TrimLeft(typ, "*[]")
+	i := strings.LastIndex(typ, ".")
+	if i < 0 || i < strings.LastIndex(typ, "/") {
+		return "", typ
+	}
+	return typ[:i], typ[i+1:]
+}
+
+// pkgName returns the probable package name for an import path: the last
+// element without any version suffix, "go-" prefix, or "-" and "."
+// characters.
+func pkgName(p string) string {
+	name := path.Base(p)
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.Replace(name, "-", "", -1)
+	return strings.Replace(name, ".", "", -1)
+}
@@ -0,0 +1,95 @@
+package dbsql2go
+
+import "testing"
+
+func TestImportsAdd(t *testing.T) {
+	cfg := Config{Types: map[string]string{
+		"abc.amount": "github.com/shopspring/decimal.Decimal",
+		"abc.rate":   "*github.com/other/decimal.Decimal",
+		"abc.count":  "int64",
+	}}
+	imports := NewImports(&cfg)
+	tests := []struct {
+		path string
+		name string
+	}{
+		{"database/sql", "sql"},
+		{"github.com/go-sql-driver/mysql", "mysql"},
+		{"github.com/mohae/dbsql2go/uuid", "uuid"},
+		{"github.com/other/decimal", "decimal"},
+		{"github.com/shopspring/decimal", "shopspringdecimal"},
+		{"github.com/example/mysql", "examplemysql"},
+		{"github.com/other/example/mysql", "mysql2"},
+		{"gopkg.in/yaml.v2", "yaml"},
+		{"github.com/lib/go-uuid", "libuuid"},
+	}
+	for _, test := range tests {
+		name := imports.Add(test.path)
+		if name != test.name {
+			t.Errorf("%s: got %q want %q", test.path, name, test.name)
+		}
+	}
+
+	types := []struct {
+		typ      string
+		expected string
+	}{
+		{"int64", "int64"},
+		{"github.com/shopspring/decimal.Decimal", "shopspringdecimal.Decimal"},
+		{"*github.com/other/decimal.Decimal", "*decimal.Decimal"},
+		{"[]github.com/example/mysql.NullTime", "[]examplemysql.NullTime"},
+	}
+	for _, test := range types {
+		got := imports.Type(test.typ)
+		if got != test.expected {
+			t.Errorf("%s: got %q want %q", test.typ, got, test.expected)
+		}
+	}
+
+	typ, ok := cfg.ColumnType("abc", "amount")
+	if !ok || typ != "shopspringdecimal.Decimal" {
+		t.Errorf("ColumnType: got %q, %t want %q, true", typ, ok, "shopspringdecimal.Decimal")
+	}
+	_, ok = cfg.ColumnType("def", "amount")
+	if ok {
+		t.Error("ColumnType: def.amount: got true want false")
+	}
+}
+
+func TestImportsDecl(t *testing.T) {
+	cfg := Config{Types: map[string]string{"abc.amount": "github.com/example/mysql.Decimal"}}
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"none", "type Abc struct {\n\tID int32\n}\n", ""},
+		{
+			"std only", "type Abc struct {\n\tID sql.NullInt64\n\tWhen time.Time\n}\n",
+			"import (\n\t\"database/sql\"\n\t\"time\"\n)\n",
+		},
+		{
+			"grouped", "type Abc struct {\n\tID sql.Null[int8]\n\tWhen mysql.NullTime\n\tAmount examplemysql.Decimal\n}\n",
+			"import (\n\t\"database/sql\"\n\n\texamplemysql \"github.com/example/mysql\"\n\t\"github.com/go-sql-driver/mysql\"\n)\n",
+		},
+		{
+			// locals and receivers are not packages.
+			"locals", "func (a *Abc) Insert(db *sql.DB) error {\n\ttime := a.When\n\t_ = time.Unix()\n\tid, err := uuid.Generate()\n\t_ = id\n\treturn err\n}\n",
+			"import (\n\t\"database/sql\"\n\n\t\"github.com/mohae/dbsql2go/uuid\"\n)\n",
+		},
+	}
+	for _, test := range tests {
+		decl, err := NewImports(&cfg).Decl([]byte(test.src))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		if string(decl) != test.expected {
+			t.Errorf("%s: got %q want %q", test.name, decl, test.expected)
+		}
+	}
+	_, err := NewImports(&cfg).Decl([]byte("type Abc struct {"))
+	if err == nil {
+		t.Error("invalid source: expected an error; got none")
+	}
+}
@@ -20,14 +20,16 @@ import (
 	"database/sql"
 	"fmt"
 	"go/format"
+	"go/token"
 	"io"
 	"reflect"
+	"strconv"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/mohae/dbsql2go"
-	"github.com/mohae/int2word"
 	"github.com/mohae/mixedcase"
 )
 
@@ -35,12 +37,103 @@ const (
 	schema                 = "information_schema"
 	viewType               = "VIEW"
 	selectPKComment        = "Select SELECTs the row from %s that corresponds with the struct's primary key and populates the struct with the SELECTed data. Any error that occurs will be returned."
-	selectPKInRangeComment = "%sSelectInRange%s SELECTs a range of rows from the %s table whose PK values are within the specified range and returns a slice of %s structs. The range values are %s. %s args must be passed for the values of the query's range boundaries in the WHERE clause. The WHERE clause is in the form of %q. If there is an error, the error will be returned and the results slice will be nil."
-	deletePKComment        = "Delete DELETEs the row from %s that corresponds with the struct's primary key, if there is any. The number of rows DELETEd is returned. If an error occurs during the DELETE, an error will be returned along with 0."
-	insertPKComment        = "Insert INSERTs the data in the struct into %s. The ID from the INSERT, if applicable, is returned. If an error occurs that is returned along with a 0."
+	selectPKInRangeComment = "%sSelectInRange%s SELECTs a range of rows from the %s table whose PK values are within the specified range and returns a slice of %s structs. The range values are %s. The WHERE clause is in the form of %q. If there is an error, the error will be returned and the results slice will be nil."
+	rangeTupleComment      = "%sSelectInRange%s SELECTs the rows from the %s table whose PKs are between from and to and returns a slice of %s structs. The range values are %s. The PKs are compared as a whole, column by column in order, like an ORDER BY of the PK: the WHERE clause is in the form of %q. If there is an error, the error will be returned and the results slice will be nil."
+	rangeBoxComment        = " Each of the PK's columns is compared with its own range, so only the rows whose PKs are within the box formed by the ranges are SELECTed, not every row whose PK is between the bounds in key order."
+	deletePKComment        = "%s DELETEs the row from %s that corresponds with the struct's primary key, if there is any. The number of rows DELETEd is returned. If an error occurs during the DELETE, an error will be returned along with 0."
+	insertPKComment        = "Insert INSERTs the data in the struct into %s.%s If an error occurs, it is returned."
+	insertResultComment    = "Insert INSERTs the data in the struct into %s and returns the INSERT's sql.Result.%s If an error occurs, it is returned along with a nil sql.Result."
+	insertIDComment        = " The struct's %s is set to the ID generated for %s."
+	deleteExactComment     = "DeleteExact DELETEs a row from %s whose values are all equal to the struct's. As %s doesn't have a key, a row can only be identified by all of its values, which are compared using <=>: a NULL is equal to a NULL. If there are duplicate rows, only one of them is DELETEd. The number of rows DELETEd, 0 or 1, is returned. If an error occurs during the DELETE, an error will be returned along with 0."
+	updateExactComment     = "UpdateExact UPDATEs a row in %s whose values are all equal to orig's, e.g. the struct as it was SELECTed, with the struct's values. As %s doesn't have a key, a row can only be identified by all of its values, which are compared using <=>: a NULL is equal to a NULL. If there are duplicate rows, only one of them is UPDATEd. The number of rows affected, 0 or 1, is returned. If an error occurs, the error will be returned along with 0."
+	keylessComment         = " As %s doesn't have a primary key, or a unique key whose columns are NOT NULL, its rows are identified by all of their values; see DeleteExact and UpdateExact."
+	surrogateKeyComment    = " As %s doesn't have a primary key, its rows are identified by its %s unique key, whose columns are NOT NULL."
 	updatePKComment        = "Update UPDATEs the row in %s that corresponds with the struct's key values. The number of rows affected by the update will be returned. If an error occurs, the error will be returned along with 0."
+	contextComment         = "%[1]sContext is %[1]s using ctx for the query. If ctx is canceled, or its deadline is exceeded, before the query completes, the query is canceled and an error is returned."
+	stmtsComment           = "%sStmts holds the prepared statements for the %s table's operations. The statements are prepared by Prepare%sStmts and should be closed, using Close, when they are no longer needed."
+	prepareStmtsComment    = "Prepare%sStmts prepares the statements for the %s table's operations. If an error occurs, the statements that have been prepared are closed and the error is returned."
+	stmtsCloseComment      = "Close closes all of the prepared statements. The first error encountered, if any, is returned."
+	stmtsMethodComment     = "%s is %s using the prepared statement."
+	upsertComment          = "Upsert INSERTs the data in the struct into %s or, if the row already exists, UPDATEs the existing row using ON DUPLICATE KEY UPDATE. The number of rows affected is returned: 1 if the row was INSERTed, 2 if the row was UPDATEd, and 0 if the existing row was unchanged. If an error occurs, the error will be returned along with 0."
+	upsertBatchComment     = "%sUpsertBatch upserts the rows into %s, using multi-row INSERTs of up to %d rows each with ON DUPLICATE KEY UPDATE. The ID of the first row INSERTed, if applicable, and the number of rows affected are returned: each row INSERTed counts as 1 and each row UPDATEd counts as 2. If an error occurs, it is returned along with the ID and the number of rows affected by the statements that succeeded; use a transaction to make them atomic."
+	uniqueLookupComment    = "%s SELECTs the row from %s whose %s match the arguments, using the %s unique constraint. If there isn't a matching row, a NotFoundErr is returned."
+	parentComment          = "%s SELECTs the %s row that %c references using the %s foreign key. If there isn't a matching row, a NotFoundErr is returned."
+	parentNullComment      = " If any of the foreign key's columns are NULL, nil is returned."
+	childrenComment        = "%s SELECTs the %s rows that reference %c using the %s foreign key."
+	keyTypeComment         = "%s is the key of a %s row: the values of its %s columns. NULL columns have their zero value."
+	keyMethodComment       = "%s returns the row's %s."
+	fkKeyMethodComment     = "%s returns the %s of the %s row that %c references using the %s foreign key."
+	loadChildrenComment    = "%s SELECTs the %s rows that reference the %s using the %s foreign key and returns them by the %s of the row that they reference. One query is made for every %d distinct keys; %s whose key columns are NULL are skipped."
+	loadParentsComment     = "%s SELECTs the %s rows that the %s reference using the %s foreign key and returns them by their %s. One query is made for every %d distinct keys; %s whose foreign key columns are NULL are skipped."
+	pageAfterComment       = "%s SELECTs, in primary key order, up to limit rows from %s whose primary key comes after the key, after. If after is nil, the first rows are SELECTed. If a full page is returned, next is the key of its last row: pass it as after to get the next page; otherwise next is nil."
+	pageBeforeComment      = "%s SELECTs up to limit rows from %s whose primary key comes before the key, before, and returns them in primary key order. If before is nil, the last rows are SELECTed. If a full page is returned, next is the key of its first row: pass it as before to get the previous page; otherwise next is nil."
+	insertBatchComment     = "%sInsertBatch INSERTs the rows into %s using multi-row INSERTs of up to %d rows each. The ID of the first row INSERTed, if applicable, and the number of rows affected are returned. If an error occurs, it is returned along with the ID and the number of rows affected by the INSERTs that succeeded; use a transaction to make the INSERTs atomic."
+	rowsComment            = "%[1]sRows iterates over the rows of a query on %[2]s, one row at a time, instead of accumulating them in a slice. Each row is scanned into the same %[1]s, whose fields are overwritten by Next, and copied out using Scan. The %[1]sRows must be closed, using Close, when it is no longer needed."
+	newRowsComment         = "New%[1]sRows returns the %[1]sRows that iterates over rows, which must be the result of a query that SELECTs all of %[2]s's columns, in order, e.g. \"SELECT %[3]s FROM %[2]s\"."
+	rangeRowsComment       = "%[1]sRows is %[1]sContext returning the rows as an iterator, %[2]sRows, instead of a slice."
+	columnTypeComment      = "%[1]sColumn is one of %[2]s's columns. The %[1]sColumn constants specify the columns that %[1]sOrderBy orders the rows by."
+	selectAllComment       = "%[1]sSelectAll SELECTs the rows of %[2]s, using the options, and returns a slice of %[1]s structs. Without any options, all of the rows are SELECTed in no particular order. If an option is invalid, an InvalidOptionErr is returned."
+	selectAllRowsComment   = "%[1]sSelectAllRows is %[1]sSelectAll returning the rows as an iterator, %[1]sRows, instead of a slice."
+	orderByComment         = "%[1]sOrderBy orders the rows SELECTed by %[1]sSelectAll by col in the direction dir. If there is more than one %[1]sOrderBy, the rows are ordered by the columns in the order in which the options are passed."
+	limitComment           = "%[1]sLimit limits the number of rows SELECTed by %[1]sSelectAll to n."
+	offsetComment          = "%[1]sOffset skips the first n rows that %[1]sSelectAll would SELECT."
+	forUpdateComment       = "%[1]sForUpdate locks the rows SELECTed by %[1]sSelectAll, using FOR UPDATE, until the end of the transaction that they are SELECTed in."
+	changedComment         = "%s returns the columns, in column order, that have been changed using the setters since the row was created or last SELECTed, INSERTed, or UPDATEd."
+	setterComment          = "Set%s sets %s and marks the %s column as changed for UpdateChanged."
+	updateColumnsComment   = "UpdateColumns UPDATEs only the columns, cols, of the row in %s that corresponds with the struct's primary key. Once the UPDATE succeeds, the columns are no longer marked as changed. If a column can't be UPDATEd, because it is part of the primary key or is an auto-increment column, an InvalidColumnErr is returned. If there aren't any columns, nothing is UPDATEd. The number of rows affected is returned."
+	updateChangedComment   = "UpdateChanged UPDATEs only the columns that have been changed using the setters, see %s, of the row in %s that corresponds with the struct's primary key. Once the UPDATE succeeds, the columns are no longer marked as changed. If no columns have been changed, nothing is UPDATEd. The number of rows affected is returned."
+	softDeleteComment      = "Delete soft deletes the row in %s that corresponds with the struct's primary key by setting its %s to the current time. Once deleted, the row is only SELECTed by the WithDeleted variants of the SELECTs; use HardDelete to DELETE it. The number of rows affected is returned. If an error occurs, the error will be returned along with 0."
+	hardDeleteComment      = " Use Delete to soft delete the row instead."
+	restoreComment         = "Restore undoes the soft delete of the row in %s that corresponds with the struct's primary key by setting its %s to NULL. The number of rows affected is returned. If an error occurs, the error will be returned along with 0."
+	notDeletedComment      = " Rows that have been soft deleted, whose %s isn't NULL, aren't SELECTed%s."
+	withDeletedComment     = "%s is %s including the rows that have been soft deleted."
+	withDeletedOptComment  = "%[1]sWithDeleted includes the rows that have been soft deleted, whose %[2]s isn't NULL, in the rows SELECTed by %[1]sSelectAll."
+	timestampsComment      = " Before the %s, %s set to the current time using %s."
+	jsonTypeComment        = "%s is the JSON representation of %s used by its MarshalJSON and UnmarshalJSON methods. The NULL wrapper fields are replaced by the values that they wrap, or, for nullable columns, by pointers to them, which are nil when the column is NULL."
+	marshalJSONComment     = "MarshalJSON implements json.Marshaler. The columns are marshaled as plain values, and NULLs as null, instead of as objects, e.g. {\"String\":\"x\",\"Valid\":true}."
+	unmarshalJSONComment   = "UnmarshalJSON implements json.Unmarshaler. The columns are unmarshaled from plain values, and null as NULL. The columns that aren't in the JSON are left unchanged."
+	toJSONComment          = "toJSON returns the %s with the struct's values."
+	versionComment         = " If the row's %s doesn't match the struct's, because the row has been changed or DELETEd since it was SELECTed, nothing is %s and a StaleObjectErr is returned."
+	upsertVersionComment   = " UPDATEing the existing row increments its %s, as well as the struct's, but, unlike Update, the row's %s isn't checked against the struct's."
+	batchVersionComment    = " UPDATEing an existing row increments its %s but, unlike Update, the row's %s isn't checked against the struct's and the struct's isn't incremented."
 )
 
+// MaxPlaceholders is the maximum number of placeholders that a prepared
+// statement can have.
+const MaxPlaceholders = 65535
+
+// RowAlias is the alias used for the new row in the ON DUPLICATE KEY UPDATE
+// clause of upserts when the server supports it.
+const RowAlias = "new"
+
+// querierDecl is the declaration of the interfaces accepted by the generated
+// funcs and methods in place of a *sql.DB.
+const querierDecl = `
+// ContextQuerier is implemented by *sql.DB, *sql.Tx, and *sql.Conn. The
+// Context variants of the generated funcs and methods accept a ContextQuerier
+// so that they can be used within a transaction or on a specific connection.
+type ContextQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Querier is implemented by *sql.DB and *sql.Tx. The generated funcs and
+// methods accept a Querier so that they can be used within a transaction.
+type Querier interface {
+	ContextQuerier
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Preparer is implemented by *sql.DB, *sql.Tx, and *sql.Conn. It is used to
+// prepare the statements of each table's Stmts.
+type Preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+`
+
 type DB struct {
 	Conn        *sql.DB
 	Name        string
@@ -48,6 +141,8 @@ type DB struct {
 	indexes     []Index
 	constraints []Constraint
 	views       []dbsql2go.Viewer
+	cfg         dbsql2go.Config // the code generation options
+	Version     string          // the server's version, e.g. 8.0.32
 }
 
 // New connects to the database's information_schema using the supplied
@@ -63,12 +158,191 @@ func New(server, user, password, database string) (dbsql2go.DBer, error) {
 	}, nil
 }
 
+// SetConfig sets the options used when generating the Go code for the
+// database's tables. Any tables that have already been retrieved are updated
+// with the new options.
+func (m *DB) SetConfig(cfg dbsql2go.Config) {
+	m.cfg = cfg
+	for _, tbl := range m.tables {
+		tbl.(*Table).cfg = cfg
+	}
+}
+
+// notFoundErrDecl is the declaration of the error returned by the generated
+// lookups when a row doesn't exist.
+const notFoundErrDecl = `
+// NotFoundErr is returned by a lookup when a matching row doesn't exist. It
+// wraps sql.ErrNoRows.
+type NotFoundErr struct {
+	Table string // the table that was searched.
+}
+
+func (e NotFoundErr) Error() string {
+	return e.Table + ": no matching row"
+}
+
+// Unwrap returns sql.ErrNoRows.
+func (e NotFoundErr) Unwrap() error {
+	return sql.ErrNoRows
+}
+`
+
+// selectAllDecl is the declaration of the types shared by the generated
+// SelectAll funcs' options.
+const selectAllDecl = `
+// SortDirection is the direction in which rows are ordered by a column.
+type SortDirection int
+
+const (
+	Asc SortDirection = iota
+	Desc
+)
+
+// InvalidOptionErr is returned by a SelectAll func when one of its options is
+// invalid, e.g. a column that isn't one of the table's column constants or a
+// negative limit.
+type InvalidOptionErr struct {
+	Table  string // the table that was being SELECTed from.
+	Option string // the name of the option, e.g. OrderBy.
+}
+
+func (e InvalidOptionErr) Error() string {
+	return e.Table + ": invalid " + e.Option + " option"
+}
+`
+
+// updateColumnsDecl is the declaration of the error returned by the generated
+// UpdateColumns methods.
+const updateColumnsDecl = `
+// InvalidColumnErr is returned by an UpdateColumns method when one of the
+// columns can't be UPDATEd: it is part of the primary key, it is an
+// auto-increment column, or it isn't one of the table's column constants.
+type InvalidColumnErr struct {
+	Table  string // the table that was being UPDATEd.
+	Column int    // the column's constant.
+}
+
+func (e InvalidColumnErr) Error() string {
+	return e.Table + ": column " + strconv.Itoa(e.Column) + " can't be UPDATEd"
+}
+`
+
+// staleObjectDecl is the declaration of the errors returned by the generated
+// UPDATEs and DELETEs of tables with a version column.
+const staleObjectDecl = `
+// ErrStaleObject is matched, using errors.Is, by the StaleObjectErr returned
+// when a row with a version column has been changed, or DELETEd, since it was
+// SELECTed.
+var ErrStaleObject = errors.New("stale object")
+
+// StaleObjectErr is returned by the Update and Delete methods of a table with
+// a version column when the row's version doesn't match the struct's: the row
+// has been changed, or DELETEd, since it was SELECTed. It matches
+// ErrStaleObject.
+type StaleObjectErr struct {
+	Table string // the table that was being UPDATEd or DELETEd from.
+}
+
+func (e StaleObjectErr) Error() string {
+	return e.Table + ": stale object: the row has been changed or deleted"
+}
+
+// Is returns whether or not target is ErrStaleObject.
+func (e StaleObjectErr) Is(target error) bool {
+	return target == ErrStaleObject
+}
+`
+
+// clockDecl is the declaration of the source of the current time for the
+// timestamps that the generated code sets.
+const clockDecl = `
+// Clock is the source of the current time for the timestamps that the
+// generated code sets, e.g. when a row is INSERTed, UPDATEd, or soft deleted.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a func that implements Clock, e.g. ClockFunc(time.Now).
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// DefaultClock is the Clock used by the generated code. It can be replaced,
+// e.g. by a Clock that returns a fixed time in tests.
+var DefaultClock Clock = ClockFunc(time.Now)
+`
+
+// Shared writes the formatted code that is shared by all of the generated
+// tables: the Querier interface that the generated methods accept, the
+// NotFoundErr returned by lookups, the SortDirection and InvalidOptionErr
+// used by the SelectAll funcs' options, the InvalidColumnErr returned by the
+// UpdateColumns methods and, if there are any tables, the Stmts type that
+// holds every table's prepared statements. The StaleObjectErr is only written
+// if one of the tables has a version column and the Clock only if the
+// generated code sets one of the tables' timestamp or soft delete columns.
+func (m *DB) Shared(w io.Writer) error {
+	var tables []*Table
+	var stale, clock bool
+	for _, tbl := range m.tables {
+		t := tbl.(*Table)
+		if col, _ := t.versionColumn(); col != nil {
+			stale = true
+		}
+		if t.createdColumn() != nil || t.updatedColumn() != nil || t.softDeleteColumn() != nil {
+			clock = true
+		}
+		if !tbl.IsView() {
+			tables = append(tables, t)
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteString(querierDecl)
+	buf.WriteString(notFoundErrDecl)
+	buf.WriteString(selectAllDecl)
+	buf.WriteString(updateColumnsDecl)
+	if stale {
+		buf.WriteString(staleObjectDecl)
+	}
+	if clock {
+		buf.WriteString(clockDecl)
+	}
+	if len(tables) > 0 {
+		buf.WriteString("\n// Stmts holds the prepared statements of all of the tables.\ntype Stmts struct {\n")
+		for _, tbl := range tables {
+			fmt.Fprintf(&buf, "\t%s *%sStmts\n", tbl.structName, tbl.structName)
+		}
+		buf.WriteString("}\n\n// PrepareAll prepares the statements of all of the tables. If an error\n// occurs, the statements that have been prepared are closed and the error is\n// returned.\nfunc PrepareAll(ctx context.Context, db Preparer) (*Stmts, error) {\n\tvar stmts Stmts\n\tvar err error\n")
+		for _, tbl := range tables {
+			fmt.Fprintf(&buf, "\tstmts.%s, err = Prepare%sStmts(ctx, db)\n\tif err != nil {\n\t\tstmts.Close()\n\t\treturn nil, err\n\t}\n", tbl.structName, tbl.structName)
+		}
+		buf.WriteString("\treturn &stmts, nil\n}\n\n// Close closes the prepared statements of all of the tables. The first error\n// encountered, if any, is returned.\nfunc (stmts *Stmts) Close() error {\n\tvar err error\n")
+		for _, tbl := range tables {
+			fmt.Fprintf(&buf, "\tif stmts.%s != nil {\n\t\tcerr := stmts.%s.Close()\n\t\tif cerr != nil && err == nil {\n\t\t\terr = cerr\n\t\t}\n\t}\n", tbl.structName, tbl.structName)
+		}
+		buf.WriteString("\treturn err\n}\n")
+	}
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format shared code: %s", err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
 // Get retrieves all of the table, view, index, and constraint info for a
 // database. The tables will have information about their constraints and
 // indexes. None of the other Get or Update methods need to be called when
 // using this method.
 func (m *DB) Get() error {
-	err := m.GetTables()
+	err := m.GetVersion()
+	if err != nil {
+		return err
+	}
+
+	err = m.GetTables()
 	if err != nil {
 		return err
 	}
@@ -96,6 +370,47 @@ func (m *DB) Get() error {
 	return nil
 }
 
+// GetVersion retrieves the server's version. The version determines whether
+// or not the generated upserts use a row alias, which requires MySQL 8.0.20 or
+// later.
+func (m *DB) GetVersion() error {
+	err := m.Conn.QueryRow("SELECT VERSION()").Scan(&m.Version)
+	if err != nil {
+		return err
+	}
+	for _, tbl := range m.tables {
+		tbl.(*Table).rowAlias = SupportsRowAlias(m.Version)
+	}
+	return nil
+}
+
+// SupportsRowAlias returns whether or not a server with the version supports
+// aliasing the new row of an INSERT, which was added in MySQL 8.0.20. MariaDB
+// does not support it.
+func SupportsRowAlias(version string) bool {
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return false
+	}
+	// ignore any suffix, e.g. 8.0.32-log
+	if i := strings.IndexFunc(version, func(r rune) bool { return r != '.' && !unicode.IsDigit(r) }); i >= 0 {
+		version = version[:i]
+	}
+	min := []int{8, 0, 20}
+	for i, v := range strings.Split(version, ".") {
+		if i == len(min) {
+			break
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return false
+		}
+		if n != min[i] {
+			return n > min[i]
+		}
+	}
+	return true
+}
+
 func (m *DB) GetTables() error {
 	tableS := `SELECT table_schema, table_name, table_type,
 	 	engine,	table_collation, table_comment
@@ -157,10 +472,14 @@ func (m *DB) GetTables() error {
 			}
 			// set the column's corresponding Go field name
 			c.SetFieldName()
+			c.table = tbl.Name()
 			mTbl.columns = append(mTbl.columns, c)
 		}
 		rows.Close()
+		mTbl.db = m
 		mTbl.sqlInf.Table = tbl.Name()
+		mTbl.cfg = m.cfg
+		mTbl.rowAlias = SupportsRowAlias(m.Version)
 		mTbl.structName = mixedcase.Exported(tbl.Name())
 		r, _ := utf8.DecodeRuneInString(tbl.StructName())
 		mTbl.r = unicode.ToLower(r)
@@ -169,6 +488,30 @@ func (m *DB) GetTables() error {
 	return nil
 }
 
+// table returns the table with the name. If the database doesn't have the
+// table, nil is returned.
+func (m *DB) table(name string) *Table {
+	for _, tbl := range m.tables {
+		if tbl.Name() == name {
+			return tbl.(*Table)
+		}
+	}
+	return nil
+}
+
+// foreignKeys returns the foreign keys of all of the database's tables.
+func (m *DB) foreignKeys() []dbsql2go.Constraint {
+	var fks []dbsql2go.Constraint
+	for _, tbl := range m.tables {
+		for _, c := range tbl.(*Table).constraints {
+			if c.Type == dbsql2go.FK {
+				fks = append(fks, c)
+			}
+		}
+	}
+	return fks
+}
+
 // Tables returns information about all of the tables in a databasse; this
 // includes views but not view specific information like its definition.
 func (m *DB) Tables() []dbsql2go.Tabler {
@@ -337,6 +680,9 @@ func (m *DB) UpdateTableConstraints() error {
 				continue
 			}
 			m.tables[i].(*Table).constraints = append(m.tables[i].(*Table).constraints, c)
+			if c.Type == dbsql2go.PK {
+				m.tables[i].(*Table).pk = len(m.tables[i].(*Table).constraints) - 1
+			}
 			break
 		}
 	}
@@ -389,7 +735,7 @@ func (m *DB) UpdateTableIndexes() {
 }
 
 type Table struct {
-	//db          *DB
+	db          *DB // the database that the table is in; used to find the tables it is related to.
 	name        string
 	r           rune   // the first letter of the name, in lower-case. Used as the receiver name.
 	structName  string // the name of the struct for this table
@@ -403,6 +749,8 @@ type Table struct {
 	constraints []dbsql2go.Constraint
 	pk          int               // index of the pk constraint in constraints, if there is one
 	sqlInf      dbsql2go.TableSQL // caches all columns for the table for SQL generation
+	cfg         dbsql2go.Config   // the code generation options
+	rowAlias    bool              // whether upserts alias the new row; see SupportsRowAlias
 	buf         bytes.Buffer      // buffer for holding generated stuff; this is not thread-safe
 }
 
@@ -445,7 +793,22 @@ func (t *Table) Definition(w io.Writer) error {
 		typ = "view"
 	}
 	// write the type def comment
-	_, err := w.Write([]byte(fmt.Sprintf("// %s is the Go representation of the %q %s.\n", t.structName, t.name, typ)))
+	cmt := fmt.Sprintf("%s is the Go representation of the %q %s.", t.structName, t.name, typ)
+	switch {
+	case t.IsView():
+	case t.key() == nil:
+		cmt += fmt.Sprintf(keylessComment, t.name)
+	case t.key().Type != dbsql2go.PK:
+		cmt += fmt.Sprintf(surrogateKeyComment, t.name, t.key().Name)
+	}
+	c, err := dbsql2go.StringToComments(cmt, 80)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(c))
+	if err != nil {
+		return err
+	}
 
 	_, err = w.Write([]byte("type "))
 	if err != nil {
@@ -466,7 +829,7 @@ func (t *Table) Definition(w io.Writer) error {
 		if err != nil {
 			return err
 		}
-		_, err = w.Write([]byte(col.Go()))
+		_, err = w.Write(col.Go(&t.cfg))
 		if err != nil {
 			return err
 		}
@@ -475,6 +838,13 @@ func (t *Table) Definition(w io.Writer) error {
 			return err
 		}
 	}
+	// the setters mark the columns that they change
+	if len(t.updatableColumns()) > 0 {
+		_, err = w.Write([]byte(fmt.Sprintf("\tchanged [%d]bool // the columns changed by the setters, by %sColumn.\n", len(t.columns), t.structName)))
+		if err != nil {
+			return err
+		}
+	}
 	_, err = w.Write([]byte("}\n"))
 	if err != nil {
 		return err
@@ -511,13 +881,78 @@ func (t *Table) Go(w io.Writer) error {
 		return err
 	}
 
+	_, err = t.InsertBatchFunc(w)
+	if err != nil {
+		return err
+	}
+
 	_, err = t.UpdateMethod(w)
 	if err != nil {
 		return err
 	}
 
+	_, err = t.ChangeMethods(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.JSONMethods(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.UpsertMethod(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.ForeignKeyMethods(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.KeyTypes(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.LoaderFuncs(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.PageFuncs(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.UniqueLookupFuncs(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.UpsertBatchFunc(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.RowsType(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.SelectAllFunc(w)
+	if err != nil {
+		return err
+	}
+
 	_, err = t.SelectInRangeFunc(w)
-	return nil
+	if err != nil {
+		return err
+	}
+
+	_, err = t.PreparedStmts(w)
+	return err
 }
 
 // GoFmt creates a formatted struct definition and methods and returns the
@@ -610,104 +1045,118 @@ func (t *Table) IsView() bool {
 }
 
 // SelectPKMethod generates the method for selecting a table row using its PK
-// and writes it to the writer. The number of bytes written is returned. If an
-// error occurs that is returned along with the number of bytes written. If the
-// table does not have a primary key, nothing will be written and the error will
-// be nil as this is not an error.
+// and writes it to the writer. If the table has a soft delete column, the
+// method skips soft deleted rows and a WithDeleted variant is also generated.
+// The number of bytes written is returned. If an error occurs that is returned
+// along with the number of bytes written. If the table does not have a primary
+// key, nothing will be written and the error will be nil as this is not an
+// error.
 func (t *Table) SelectPKMethod(w io.Writer) (n int64, err error) {
-	if t.pk < 0 {
+	if t.key() == nil {
 		// nothing to do
 		return 0, nil
 	}
 	// reset before usage. Everything is written to the buffer first. If the
 	// creation of this method is successful, the buffer is written to the writer.
 	t.buf.Reset()
-	err = t.buf.WriteByte(dbsql2go.LF)
-	if err != nil {
-		return 0, err
-	}
+	for _, suffix := range t.deletedSuffixes() {
+		err = t.buf.WriteByte(dbsql2go.LF)
+		if err != nil {
+			return 0, err
+		}
 
-	// write the comment
-	c, err := dbsql2go.StringToComments(fmt.Sprintf(selectPKComment, t.name), 80)
-	if err != nil {
-		return 0, err
-	}
+		// write the comment
+		s := fmt.Sprintf(selectPKComment, t.name) + t.notDeletedComment("")
+		if suffix != "" {
+			s = fmt.Sprintf(withDeletedComment, "Select"+suffix, "Select")
+		}
+		c, err := dbsql2go.StringToComments(s, 80)
+		if err != nil {
+			return 0, err
+		}
 
-	_, err = t.buf.WriteString(c)
-	if err != nil {
-		return 0, err
-	}
+		_, err = t.buf.WriteString(c)
+		if err != nil {
+			return 0, err
+		}
 
-	_, err = t.buf.WriteString(fmt.Sprintf("func(%c *%s) Select(db *sql.DB) error {\n\terr := db.QueryRow(\"", t.r, t.structName))
-	if err != nil {
-		return 0, err
-	}
+		err = t.contextFunc(true, "Select"+suffix, "", "", "error")
+		if err != nil {
+			return 0, err
+		}
 
-	err = t.selectSQLPK()
-	if err != nil {
-		return 0, err
-	}
+		_, err = t.buf.WriteString("\terr := db.QueryRowContext(ctx, \"")
+		if err != nil {
+			return 0, err
+		}
 
-	_, err = t.buf.WriteString("\", ")
-	if err != nil {
-		return 0, err
-	}
+		err = t.selectSQLPK(suffix != "")
+		if err != nil {
+			return 0, err
+		}
 
-	for i, v := range t.constraints[t.pk].Fields {
-		if i == 0 {
-			_, err = t.buf.WriteString(fmt.Sprintf("%c.%s", t.r, v))
+		_, err = t.buf.WriteString("\", ")
+		if err != nil {
+			return 0, err
+		}
+
+		for i, v := range t.key().Fields {
+			if i == 0 {
+				_, err = t.buf.WriteString(fmt.Sprintf("%c.%s", t.r, v))
+				if err != nil {
+					return 0, err
+				}
+				continue
+			}
+			_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, v))
 			if err != nil {
 				return 0, err
 			}
-			continue
 		}
-		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, v))
+
+		_, err = t.buf.WriteString(").Scan(")
 		if err != nil {
 			return 0, err
 		}
-	}
-
-	_, err = t.buf.WriteString(").Scan(")
-	if err != nil {
-		return 0, err
-	}
 
-	// buld the struct field stuff
-	for i, v := range t.columns {
-		if i == 0 {
-			_, err = t.buf.WriteString(fmt.Sprintf("&%c.%s", t.r, v.fieldName))
+		// buld the struct field stuff
+		for i, v := range t.columns {
+			if i == 0 {
+				_, err = t.buf.WriteString(fmt.Sprintf("&%c.%s", t.r, v.fieldName))
+				if err != nil {
+					return 0, err
+				}
+				continue
+			}
+			_, err = t.buf.WriteString(fmt.Sprintf(", &%c.%s", t.r, v.fieldName))
 			if err != nil {
 				return 0, err
 			}
-			continue
 		}
-		_, err = t.buf.WriteString(fmt.Sprintf(", &%c.%s", t.r, v.fieldName))
+
+		_, err = t.buf.WriteString(")\n\tif err != nil {\n\t\treturn err\n\t}\n" + t.resetChanged() + "\treturn nil\n}")
 		if err != nil {
 			return 0, err
 		}
-	}
-
-	_, err = t.buf.WriteString(")\n\tif err != nil {\n\t\treturn err\n\t}\n\treturn nil\n}")
-	if err != nil {
-		return 0, err
-	}
 
-	err = t.buf.WriteByte(dbsql2go.LF)
-	if err != nil {
-		return 0, err
+		err = t.buf.WriteByte(dbsql2go.LF)
+		if err != nil {
+			return 0, err
+		}
 	}
 
 	return t.buf.WriteTo(w)
 }
 
 // selectSQLPK returns a SELECT statement for the table that selects all the
-// table columns using the tables PK. If the table does not have a PK, a nil
-// will be returned and the error will also be nil as this is not an error
-// state.
-func (t *Table) selectSQLPK() error {
+// table columns using the tables PK. Soft deleted rows aren't SELECTed unless
+// withDeleted is true. If the table does not have a PK, a nil will be returned
+// and the error will also be nil as this is not an error state.
+func (t *Table) selectSQLPK(withDeleted bool) error {
 	// set up the relevant infor for the SQL generation; Table is already set.
 	t.sqlInf.Columns = t.ColumnNames()
-	t.sqlInf.WhereColumns = t.constraints[t.pk].Columns
+	t.sqlInf.WhereColumns = t.key().Columns
+	t.sqlInf.WhereNull = t.whereNotDeleted(withDeleted)
 	err := dbsql2go.SelectSQL.Execute(&t.buf, t.sqlInf)
 	if err != nil {
 		return err
@@ -716,30 +1165,19 @@ func (t *Table) selectSQLPK() error {
 }
 
 // SelectInRangeSQL creates in range SELECT funcs for the table if it has a
-// primary key. Tables without priamry keys wiill have nothing written to the
-// writer and 0 will be returned for the number of bytes written along with nil
-// for the error. Any error encountered is written along with the number of
-// bytes for the table.
+// primary key. If the table has a soft delete column, the funcs skip soft
+// deleted rows and WithDeleted variants are also generated. Tables without
+// priamry keys wiill have nothing written to the writer and 0 will be returned
+// for the number of bytes written along with nil for the error. Any error
+// encountered is written along with the number of bytes for the table.
 func (t *Table) SelectInRangeFunc(w io.Writer) (n int64, err error) {
-	if t.pk < 0 { // If no primary key return 0 for bytes written and nil for the error.
+	if t.key() == nil { // If no primary key return 0 for bytes written and nil for the error.
 		return 0, nil
 	}
 
 	// Prepare the Table Information for the SQL
 	t.sqlInf.Columns = t.ColumnNames()
-	// Reset the where info
-	t.sqlInf.WhereColumns = t.sqlInf.WhereColumns[:0]
-	t.sqlInf.WhereConditions = t.sqlInf.WhereConditions[:0]
-
-	// for Where columns, each pk column is used twice to set up >= <=.
-	for i, col := range t.constraints[t.pk].Columns {
-		if i != 0 {
-			t.sqlInf.WhereConditions = append(t.sqlInf.WhereConditions, "AND")
-		}
-		t.sqlInf.WhereColumns = append(t.sqlInf.WhereColumns, col)
-		t.sqlInf.WhereColumns = append(t.sqlInf.WhereColumns, col)
-		t.sqlInf.WhereConditions = append(t.sqlInf.WhereConditions, "AND")
-	}
+	t.setInRangeWhere()
 
 	n, err = t.selectInRangeExclusive(w)
 	if err != nil {
@@ -755,23 +1193,59 @@ func (t *Table) SelectInRangeFunc(w io.Writer) (n int64, err error) {
 	return n, nil
 }
 
-func (t *Table) selectInRangeExclusive(w io.Writer) (n int64, err error) {
+// setInRangeWhere sets the WHERE columns and conditions for the in range
+// SELECTs: each pk column is used twice, once for each end of the range.
+func (t *Table) setInRangeWhere() {
+	// Reset the where info; new slices are used as WhereColumns may be a pk's
+	// Columns.
+	t.sqlInf.WhereColumns = nil
+	t.sqlInf.WhereConditions = nil
+	t.sqlInf.WhereTuple = false
+	t.sqlInf.WhereArgs = nil
+
+	// the pk is compared as a row constructor: (a, b) > (?, ?) AND (a, b) < (?, ?)
+	if t.rangeTuple() {
+		pk := t.key()
+		t.sqlInf.WhereColumns = append(t.sqlInf.WhereColumns, pk.Columns...)
+		t.sqlInf.WhereConditions = append(t.sqlInf.WhereConditions, "AND")
+		t.sqlInf.WhereTuple = true
+		t.sqlInf.WhereArgs = []string{t.fieldArgs("from", pk.Columns, false), t.fieldArgs("to", pk.Columns, false)}
+		return
+	}
+
+	// for Where columns, each pk column is used twice to set up >= <=.
+	for i, col := range t.key().Columns {
+		if i != 0 {
+			t.sqlInf.WhereConditions = append(t.sqlInf.WhereConditions, "AND")
+		}
+		t.sqlInf.WhereColumns = append(t.sqlInf.WhereColumns, col)
+		t.sqlInf.WhereColumns = append(t.sqlInf.WhereColumns, col)
+		t.sqlInf.WhereConditions = append(t.sqlInf.WhereConditions, "AND")
+	}
+	t.sqlInf.WhereArgs = t.inRangeArgs()
+}
+
+// setInRangeOps sets the comparison operators, for the start and the end of
+// the range, of the in range SELECTs. setInRangeWhere must be called first.
+func (t *Table) setInRangeOps(start, end string) {
 	t.sqlInf.WhereComparisonOps = t.sqlInf.WhereComparisonOps[:0]
-	// Set the Comparison ops for inclusive
+	if t.sqlInf.WhereTuple {
+		t.sqlInf.WhereComparisonOps = append(t.sqlInf.WhereComparisonOps, start, end)
+		return
+	}
 	for i := 0; i < len(t.sqlInf.WhereColumns)/2; i++ {
-		t.sqlInf.WhereComparisonOps = append(t.sqlInf.WhereComparisonOps, ">")
-		t.sqlInf.WhereComparisonOps = append(t.sqlInf.WhereComparisonOps, "<")
+		t.sqlInf.WhereComparisonOps = append(t.sqlInf.WhereComparisonOps, start)
+		t.sqlInf.WhereComparisonOps = append(t.sqlInf.WhereComparisonOps, end)
 	}
+}
+
+func (t *Table) selectInRangeExclusive(w io.Writer) (n int64, err error) {
+	t.setInRangeOps(">", "<")
 	return t.selectInRange(w, dbsql2go.TitleExclusive, dbsql2go.LowerExclusive)
 }
 
 func (t *Table) selectInRangeInclusive(w io.Writer) (n int64, err error) {
-	t.sqlInf.WhereComparisonOps = t.sqlInf.WhereComparisonOps[:0]
-	// Set the Comparison ops for inclusive
-	for i := 0; i < len(t.sqlInf.WhereColumns)/2; i++ {
-		t.sqlInf.WhereComparisonOps = append(t.sqlInf.WhereComparisonOps, ">=")
-		t.sqlInf.WhereComparisonOps = append(t.sqlInf.WhereComparisonOps, "<=")
-	}
+	t.setInRangeOps(">=", "<=")
 	return t.selectInRange(w, dbsql2go.TitleInclusive, dbsql2go.LowerInclusive)
 }
 
@@ -779,68 +1253,2665 @@ func (t *Table) selectInRange(w io.Writer, title, lower string) (n int64, err er
 	// reset the buffer: everything gets written to the buffer first
 	t.buf.Reset()
 
-	err = dbsql2go.SelectAndOrWhereComment.Execute(&t.buf, t.sqlInf)
-	if err != nil {
-		return 0, err
+	for _, suffix := range t.deletedSuffixes() {
+		t.sqlInf.WhereNull = t.whereNotDeleted(suffix != "")
+		name := t.structName + "SelectInRange" + title + suffix
+
+		var s string
+		switch {
+		case suffix != "":
+			s = fmt.Sprintf(withDeletedComment, name, t.structName+"SelectInRange"+title)
+		default:
+			var where bytes.Buffer
+			err = dbsql2go.SelectAndOrWhereComment.Execute(&where, t.sqlInf)
+			if err != nil {
+				return 0, err
+			}
+			if t.sqlInf.WhereTuple {
+				s = fmt.Sprintf(rangeTupleComment, t.structName, title, t.name, t.structName, lower, where.String())
+				break
+			}
+			s = fmt.Sprintf(selectPKInRangeComment, t.structName, title, t.name, t.structName, lower, where.String())
+			if len(t.key().Columns) > 1 {
+				s += rangeBoxComment
+			}
+		}
+		c, err := dbsql2go.StringToComments(s, 80)
+		if err != nil {
+			return 0, err
+		}
+
+		err = t.buf.WriteByte('\n')
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = t.buf.WriteString(c)
+		if err != nil {
+			return 0, err
+		}
+
+		params, args, sqlArgs := t.inRangeParams()
+		err = t.contextFunc(false, name, params, args, fmt.Sprintf("(results []%s, err error)", t.structName))
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = t.buf.WriteString("\trows, err := db.QueryContext(ctx, \"")
+		if err != nil {
+			return 0, err
+		}
+
+		// write the sql
+		err = dbsql2go.SelectAndOrSQL.Execute(&t.buf, t.sqlInf)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = t.buf.WriteString(fmt.Sprintf("\", %s)", sqlArgs))
+		if err != nil {
+			return 0, err
+		}
+
+		err = t.scanRows(&t.buf)
+		if err != nil {
+			return 0, err
+		}
+
+		// the Rows variant
+		c, err = dbsql2go.StringToComments(fmt.Sprintf(rangeRowsComment, name, t.structName), 80)
+		if err != nil {
+			return 0, err
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc %sRows(ctx context.Context, db ContextQuerier, %s) (*%sRows, error) {\n\trows, err := db.QueryContext(ctx, \"", c, name, params, t.structName))
+		if err != nil {
+			return 0, err
+		}
+		err = dbsql2go.SelectAndOrSQL.Execute(&t.buf, t.sqlInf)
+		if err != nil {
+			return 0, err
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf("\", %s)", sqlArgs))
+		if err != nil {
+			return 0, err
+		}
+		err = t.rowsFunc(&t.buf)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// rangeTuple returns whether or not the in range SELECTs compare the pk as a
+// row constructor. This only matters for composite keys.
+func (t *Table) rangeTuple() bool {
+	return t.cfg.RangeMode == dbsql2go.RangeTuple && len(t.key().Columns) > 1
+}
+
+// inRangeParams returns the parameters of the in range SELECTs, the
+// corresponding arguments, and the arguments for the query's placeholders.
+// A single column pk has a parameter, of the column's type, for each bound,
+// e.g. idFrom, idTo int32; a composite pk has a key for each bound, from and
+// to.
+func (t *Table) inRangeParams() (params, args, sqlArgs string) {
+	pk := t.key()
+	if t.rangeTuple() {
+		return "from, to " + t.keyTypeName(pk.Columns), "from, to", t.fieldArgs("from", pk.Columns, false) + ", " + t.fieldArgs("to", pk.Columns, false)
+	}
+	if len(pk.Columns) > 1 {
+		return "from, to " + t.keyTypeName(pk.Columns), "from, to", strings.Join(t.inRangeArgs(), ", ")
+	}
+	_, typ, _ := t.column(pk.Columns[0]).value("", &t.cfg)
+	args = strings.Join(t.inRangeArgs(), ", ")
+	return fmt.Sprintf("%s %s", args, typ), args, args
+}
+
+// inRangeArgs returns the arguments, in order, for the placeholders of the
+// in range SELECTs that compare each pk column with its own range.
+func (t *Table) inRangeArgs() []string {
+	pk := t.key()
+	if len(pk.Columns) == 1 {
+		p := paramName(pk.Columns[0])
+		return []string{p + "From", p + "To"}
+	}
+	var args []string
+	for _, v := range pk.Fields {
+		args = append(args, "from."+v, "to."+v)
+	}
+	return args
+}
+
+// scanRows writes, to buf, the rest of a func that has queried for rows of
+// the table: each row is scanned into a struct and appended to results. The
+// error, if any, that ended the iteration is returned.
+func (t *Table) scanRows(buf *bytes.Buffer) error {
+	_, err := buf.WriteString(fmt.Sprintf("\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tdefer rows.Close()\n\n\tfor rows.Next() {\n\t\tvar %c %s\n\t\terr = rows.Scan(%s)\n", t.r, t.structName, t.fieldArgs(string(t.r), t.ColumnNames(), true)))
+	if err != nil {
+		return err
+	}
+	_, err = buf.WriteString(fmt.Sprintf("\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\tresults = append(results, %c)\n\t}\n\terr = rows.Err()\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\n\treturn results, nil\n}\n", t.r))
+	return err
+}
+
+// RowsType generates the table's Rows type, an iterator over the rows of a
+// query on the table, and the func that creates one from a *sql.Rows. The
+// number of bytes written is returned along with any error that occurs.
+func (t *Table) RowsType(w io.Writer) (n int64, err error) {
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(rowsComment, t.structName, t.name), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("%stype %sRows struct {\n\trows *sql.Rows\n\trow %s\n\terr error\n}\n\n", c, t.structName, t.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	c, err = dbsql2go.StringToComments(fmt.Sprintf(newRowsComment, t.structName, t.name, strings.Join(t.ColumnNames(), ", ")), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("%sfunc New%sRows(rows *sql.Rows) *%sRows {\n\treturn &%sRows{rows: rows}\n}\n\n", c, t.structName, t.structName, t.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("// Next scans the next row. It returns false when there are no more rows or\n// when an error occurs; use Err to tell them apart.\nfunc (r *%sRows) Next() bool {\n\tif r.err != nil || !r.rows.Next() {\n\t\treturn false\n\t}\n\tr.err = r.rows.Scan(%s)\n\treturn r.err == nil\n}\n\n", t.structName, t.fieldArgs("r.row", t.ColumnNames(), true)))
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("// Scan copies the row scanned by Next into dst.\nfunc (r *%sRows) Scan(dst *%s) error {\n\tif r.err != nil {\n\t\treturn r.err\n\t}\n\t*dst = r.row\n\treturn nil\n}\n\n", t.structName, t.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("// Err returns the error, if any, that was encountered while iterating.\nfunc (r *%sRows) Err() error {\n\tif r.err != nil {\n\t\treturn r.err\n\t}\n\treturn r.rows.Err()\n}\n\n// Close closes the rows. Close is idempotent and doesn't affect the result of\n// Err.\nfunc (r *%sRows) Close() error {\n\treturn r.rows.Close()\n}\n", t.structName, t.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// SelectAllFunc generates the func that SELECTs all of the rows of the table
+// or view, along with its Rows variant, the options that order, limit, and
+// lock the rows, and the constants for the columns that the rows can be
+// ordered by. The number of bytes written is returned along with any error
+// that occurs.
+func (t *Table) SelectAllFunc(w io.Writer) (n int64, err error) {
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	// the column constants: the names are checked here so that the ORDER BY
+	// can only contain valid identifiers.
+	names := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		names[i], err = sqlIdent(col.Name)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", t.name, err)
+		}
+		names[i] = strconv.Quote(names[i])
+	}
+	unexp := unexported(t.structName)
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(columnTypeComment, t.structName, t.name), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("%stype %sColumn int\n\n// The columns of %s.\nconst (\n", c, t.structName, t.name))
+	if err != nil {
+		return 0, err
+	}
+	for i, col := range t.columns {
+		if i == 0 {
+			_, err = t.buf.WriteString(fmt.Sprintf("\t%sColumn%s %sColumn = iota\n", t.structName, col.fieldName, t.structName))
+		} else {
+			_, err = t.buf.WriteString(fmt.Sprintf("\t%sColumn%s\n", t.structName, col.fieldName))
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf(")\n\n// %sColumns are the names of %s's columns, by %sColumn.\nvar %sColumns = [...]string{%s}\n\n", unexp, t.name, t.structName, unexp, strings.Join(names, ", ")))
+	if err != nil {
+		return 0, err
+	}
+
+	// the options
+	deleted := t.softDeleteColumn()
+	var withDeleted, deletedName string
+	if deleted != nil {
+		withDeleted, deletedName = "\twithDeleted bool\n", deleted.Name
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("// %[1]sOption is an option of %[1]sSelectAll.\ntype %[1]sOption func(*%[2]sOptions)\n\n// %[2]sOptions are the options of %[1]sSelectAll.\ntype %[2]sOptions struct {\n\torderBy []string\n\tlimit int // -1 if the rows aren't limited.\n\toffset int\n\tforUpdate bool\n%[3]s\terr error // the first invalid option's error.\n}\n", t.structName, unexp, withDeleted))
+	if err != nil {
+		return 0, err
+	}
+	invalid := fmt.Sprintf("\t\t\to.err = InvalidOptionErr{Table: %q, Option: %%q}\n", t.name)
+	opts := []struct {
+		comment, name, params, body string
+	}{
+		{orderByComment, "OrderBy", "col " + t.structName + "Column, dir SortDirection", fmt.Sprintf("\t\tif col < 0 || int(col) >= len(%[1]sColumns) {\n%[2]s\t\t\treturn\n\t\t}\n\t\tswitch dir {\n\t\tcase Asc:\n\t\t\to.orderBy = append(o.orderBy, %[1]sColumns[col])\n\t\tcase Desc:\n\t\t\to.orderBy = append(o.orderBy, %[1]sColumns[col]+\" DESC\")\n\t\tdefault:\n%[2]s\t\t}\n", unexp, fmt.Sprintf(invalid, "OrderBy"))},
+		{limitComment, "Limit", "n int", fmt.Sprintf("\t\tif n < 0 {\n%s\t\t\treturn\n\t\t}\n\t\to.limit = n\n", fmt.Sprintf(invalid, "Limit"))},
+		{offsetComment, "Offset", "n int", fmt.Sprintf("\t\tif n < 0 {\n%s\t\t\treturn\n\t\t}\n\t\to.offset = n\n", fmt.Sprintf(invalid, "Offset"))},
+		{forUpdateComment, "ForUpdate", "", "\t\to.forUpdate = true\n"},
+	}
+	if deleted != nil {
+		opts = append(opts, struct{ comment, name, params, body string }{withDeletedOptComment, "WithDeleted", "", "\t\to.withDeleted = true\n"})
+	}
+	for _, opt := range opts {
+		c, err = dbsql2go.StringToComments(fmt.Sprintf(opt.comment, t.structName, deletedName), 80)
+		if err != nil {
+			return 0, err
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc %s%s(%s) %sOption {\n\treturn func(o *%sOptions) {\n%s\t}\n}\n", c, t.structName, opt.name, opt.params, t.structName, unexp, opt.body))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// the query
+	_, err = t.buf.WriteString(fmt.Sprintf("\n// %[1]sSelectAllQuery returns the query, and its arguments, for the options.\nfunc %[1]sSelectAllQuery(opts []%[2]sOption) (query string, args []interface{}, err error) {\n\to := %[1]sOptions{limit: -1}\n\tfor _, opt := range opts {\n\t\topt(&o)\n\t}\n\tif o.err != nil {\n\t\treturn \"\", nil, o.err\n\t}\n\tquery = \"", unexp, t.structName))
+	if err != nil {
+		return 0, err
+	}
+	t.sqlInf.Columns = t.ColumnNames()
+	t.sqlInf.WhereColumns = nil
+	t.sqlInf.WhereNull = nil
+	err = dbsql2go.SelectSQL.Execute(&t.buf, t.sqlInf)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString("\"\n")
+	if err != nil {
+		return 0, err
+	}
+	if deleted != nil {
+		_, err = t.buf.WriteString(fmt.Sprintf("\tif !o.withDeleted {\n\t\tquery += \" WHERE %s IS NULL\"\n\t}\n", deleted.Name))
+		if err != nil {
+			return 0, err
+		}
+	}
+	_, err = t.buf.WriteString("\tif len(o.orderBy) > 0 {\n\t\tquery += \" ORDER BY \" + strings.Join(o.orderBy, \", \")\n\t}\n\tswitch {\n\tcase o.limit >= 0:\n\t\tquery += \" LIMIT ?\"\n\t\targs = append(args, o.limit)\n\tcase o.offset > 0: // an OFFSET requires a LIMIT.\n\t\tquery += \" LIMIT 18446744073709551615\"\n\t}\n\tif o.offset > 0 {\n\t\tquery += \" OFFSET ?\"\n\t\targs = append(args, o.offset)\n\t}\n\tif o.forUpdate {\n\t\tquery += \" FOR UPDATE\"\n\t}\n\treturn query, args, nil\n}\n")
+	if err != nil {
+		return 0, err
+	}
+
+	// the funcs
+	c, err = dbsql2go.StringToComments(fmt.Sprintf(selectAllComment, t.structName, t.name)+t.notDeletedComment(t.structName+"WithDeleted is used"), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc %sSelectAll(ctx context.Context, db ContextQuerier, opts ...%sOption) (results []%s, err error) {\n\tquery, args, err := %sSelectAllQuery(opts)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\trows, err := db.QueryContext(ctx, query, args...)", c, t.structName, t.structName, t.structName, unexp))
+	if err != nil {
+		return 0, err
+	}
+	err = t.scanRows(&t.buf)
+	if err != nil {
+		return 0, err
+	}
+	c, err = dbsql2go.StringToComments(fmt.Sprintf(selectAllRowsComment, t.structName), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc %sSelectAllRows(ctx context.Context, db ContextQuerier, opts ...%sOption) (*%sRows, error) {\n\tquery, args, err := %sSelectAllQuery(opts)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\trows, err := db.QueryContext(ctx, query, args...)", c, t.structName, t.structName, t.structName, unexp))
+	if err != nil {
+		return 0, err
+	}
+	err = t.rowsFunc(&t.buf)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// sqlIdent returns name as it is used in generated SQL. Names that aren't
+// made up of only letters, digits, _, and $ are quoted. A name that can't be
+// quoted, because it contains a backtick, is an error.
+func sqlIdent(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("empty identifier")
+	}
+	plain := true
+	for _, r := range name {
+		if r == '`' {
+			return "", fmt.Errorf("%q: identifiers containing a backtick are not supported", name)
+		}
+		if !(r == '_' || r == '$' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			plain = false
+		}
+	}
+	if plain {
+		return name, nil
+	}
+	return "`" + name + "`", nil
+}
+
+// rowsFunc writes the rest of a func that has queried for rows of the table:
+// the rows are returned as the table's Rows type.
+func (t *Table) rowsFunc(buf *bytes.Buffer) error {
+	_, err := buf.WriteString(fmt.Sprintf("\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn New%sRows(rows), nil\n}\n", t.structName))
+	return err
+}
+
+// fieldArgs returns the comma separated list of the fields of v, a variable
+// of the table's struct type, for the columns in cols. If addr is true, the
+// address of each field is used.
+func (t *Table) fieldArgs(v string, cols []string, addr bool) string {
+	var args []string
+	for _, name := range cols {
+		for _, col := range t.columns {
+			if col.Name != name {
+				continue
+			}
+			if addr {
+				args = append(args, fmt.Sprintf("&%s.%s", v, col.fieldName))
+				break
+			}
+			args = append(args, fmt.Sprintf("%s.%s", v, col.fieldName))
+			break
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// DeletePKMethod generates the method for deleting a table row using its PK
+// and writes it to the writer. If the table has a soft delete column, Delete
+// soft deletes the row instead and the HardDelete and Restore methods are also
+// generated. The number of bytes written is returned. If an error occurs that
+// is returned along with the number of bytes written. If the table does not
+// have a key, the DeleteExact method is generated instead. Views don't have a
+// delete method, which is not an error.
+func (t *Table) DeletePKMethod(w io.Writer) (n int64, err error) {
+	if t.IsView() {
+		return 0, nil // nothing to do
+	}
+	// Reset the buffer so this method can use it.
+	t.buf.Reset()
+	if t.key() == nil {
+		err = t.deleteExact()
+		if err != nil {
+			return 0, err
+		}
+		return t.buf.WriteTo(w)
+	}
+	if t.softDeleteColumn() == nil {
+		err = t.deletePK("Delete")
+		if err != nil {
+			return 0, err
+		}
+		return t.buf.WriteTo(w)
+	}
+
+	err = t.softDeletePK(false)
+	if err != nil {
+		return 0, err
+	}
+	err = t.deletePK("HardDelete")
+	if err != nil {
+		return 0, err
+	}
+	err = t.softDeletePK(true)
+	if err != nil {
+		return 0, err
+	}
+	return t.buf.WriteTo(w)
+}
+
+// deleteExact writes the DeleteExact method, which DELETEs a row of a table
+// without a key using all of its values, to the buffer.
+func (t *Table) deleteExact() error {
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(deleteExactComment, t.name, t.name), 80)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString("\n" + c)
+	if err != nil {
+		return err
+	}
+	err = t.contextFunc(true, "DeleteExact", "", "", "(n int64, err error)")
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString("\tres, err := db.ExecContext(ctx, \"")
+	if err != nil {
+		return err
+	}
+	inf := t.exactSQLInf()
+	err = dbsql2go.DeleteSQL.Execute(&t.buf, inf)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\", %s)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n\treturn res.RowsAffected()\n}\n", t.fieldArgs(string(t.r), inf.WhereColumns, false)))
+	return err
+}
+
+// updateExact writes the UpdateExact method, which UPDATEs a row of a table
+// without a key using all of its original values, to the buffer.
+func (t *Table) updateExact() error {
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(updateExactComment, t.name, t.name)+t.timestampsComment("UPDATE", false), 80)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString("\n" + c)
+	if err != nil {
+		return err
+	}
+	err = t.contextFunc(true, "UpdateExact", "orig *"+t.structName, "orig", "(n int64, err error)")
+	if err != nil {
+		return err
+	}
+	err = t.setTimestamps(string(t.r), false)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString("\tres, err := db.ExecContext(ctx, \"")
+	if err != nil {
+		return err
+	}
+	inf := t.exactSQLInf()
+	inf.Columns = t.ColumnNames()
+	err = dbsql2go.UpdateSQL.Execute(&t.buf, inf)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\", %s, %s)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n\treturn res.RowsAffected()\n}\n", t.fieldArgs(string(t.r), inf.Columns, false), t.fieldArgs("orig", inf.WhereColumns, false)))
+	return err
+}
+
+// exactSQLInf returns the information for the SQL of the statements that
+// affect a row of a table without a key: all of the columns are compared
+// using <=>, which is NULL-safe, and only one row, of any duplicates, is
+// affected.
+func (t *Table) exactSQLInf() dbsql2go.TableSQL {
+	return dbsql2go.TableSQL{Table: t.name, WhereColumns: t.ColumnNames(), WhereNullSafe: true, Limit: 1}
+}
+
+// deletePK writes the method, name, that DELETEs the row using its PK to the
+// buffer.
+func (t *Table) deletePK(name string) error {
+	err := t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return err
+	}
+
+	// write the comment
+	cmt := fmt.Sprintf(deletePKComment, name, t.name)
+	if col, _ := t.versionColumn(); col != nil {
+		cmt += fmt.Sprintf(versionComment, col.Name, "DELETEd")
+	}
+	if t.softDeleteColumn() != nil {
+		cmt += hardDeleteComment
+	}
+	c, err := dbsql2go.StringToComments(cmt, 80)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.buf.WriteString(c)
+	if err != nil {
+		return err
+	}
+
+	err = t.contextFunc(true, name, "", "", "(n int64, err error)")
+	if err != nil {
+		return err
+	}
+
+	_, err = t.buf.WriteString("\tres, err := db.ExecContext(ctx, \"")
+	if err != nil {
+		return err
+	}
+
+	err = t.deleteSQLPK()
+	if err != nil {
+		return err
+	}
+
+	_, err = t.buf.WriteString("\", ")
+	if err != nil {
+		return err
+	}
+
+	for i, v := range t.key().Fields {
+		if i == 0 {
+			_, err = t.buf.WriteString(fmt.Sprintf("%c.%s", t.r, v))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, v))
+		if err != nil {
+			return err
+		}
+	}
+	if col, _ := t.versionColumn(); col != nil {
+		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, col.fieldName))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = t.buf.WriteString(")\n\tif err != nil {\n\t\treturn 0, err\n\t}\n" + t.versionResult(false, "", "") + "}\n")
+	return err
+}
+
+// deleteSQLPK returns a DELETE statement for the table that deletes a row
+// using the tables PK. If the table does not have a PK, no SQL will be
+// generated and a nil will be returned as this is not an error state.
+func (t *Table) deleteSQLPK() error {
+	t.sqlInf.WhereColumns = t.versionWhere()
+	err := dbsql2go.DeleteSQL.Execute(&t.buf, t.sqlInf)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// softDeletePK writes the Delete method, which soft deletes the row using its
+// PK, or, if restore is true, the Restore method, which undoes the soft
+// delete, to the buffer. Both are UPDATEs of the soft delete column.
+func (t *Table) softDeletePK(restore bool) error {
+	col := t.softDeleteColumn()
+	name, cmt, arg, val := "Delete", fmt.Sprintf(softDeleteComment, t.name, col.Name), "now", col.timeField("now", &t.cfg)
+	if restore {
+		name, cmt, arg, val = "Restore", fmt.Sprintf(restoreComment, t.name, col.Name), "nil", col.timeField("", &t.cfg)
+	}
+	if v, _ := t.versionColumn(); v != nil {
+		cmt += fmt.Sprintf(versionComment, v.Name, "UPDATEd")
+	}
+	c, err := dbsql2go.StringToComments(cmt, 80)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString("\n" + c)
+	if err != nil {
+		return err
+	}
+
+	err = t.contextFunc(true, name, "", "", "(n int64, err error)")
+	if err != nil {
+		return err
+	}
+	if !restore {
+		_, err = t.buf.WriteString(fmt.Sprintf("\tnow := %s\n", t.now()))
+		if err != nil {
+			return err
+		}
+	}
+	_, err = t.buf.WriteString("\tres, err := db.ExecContext(ctx, \"")
+	if err != nil {
+		return err
+	}
+	err = t.softDeleteSQL()
+	if err != nil {
+		return err
+	}
+	row := fmt.Sprintf("db.QueryRowContext(ctx, %q, %s)", t.versionSelectSQL(), t.fieldArgs(string(t.r), t.key().Columns, false))
+	_, err = t.buf.WriteString(fmt.Sprintf("\", %s, %s)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n%s}\n", arg, t.fieldArgs(string(t.r), t.versionWhere(), false), t.versionResult(true, row, fmt.Sprintf("\t%c.%s = %s\n", t.r, col.fieldName, val))))
+	return err
+}
+
+// softDeleteSQL writes the UPDATE that sets the soft delete column of a row,
+// using the table's PK, to the buffer. Like any other UPDATE, it increments
+// the version, if the table has one.
+func (t *Table) softDeleteSQL() error {
+	t.sqlInf.Columns = []string{t.softDeleteColumn().Name}
+	t.sqlInf.WhereColumns = t.versionWhere()
+	t.sqlInf.IncrementColumns = nil
+	if col, ts := t.versionColumn(); col != nil && !ts {
+		t.sqlInf.IncrementColumns = []string{col.Name}
+	}
+	return dbsql2go.UpdateSQL.Execute(&t.buf, t.sqlInf)
+}
+
+// InsertMethod generates the method for inserting the Table's data into the
+// db table as a row. The number of bytes written to the writer is returned
+// along with any error that may occur, if any. If the table is a view, no
+// insert method will be generated.
+func (t *Table) InsertMethod(w io.Writer) (n int64, err error) {
+	if t.IsView() {
+		return 0, nil
+	}
+
+	t.buf.Reset()
+
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	// write the comment
+	c, err := dbsql2go.StringToComments(t.insertComment(), 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(c)
+	if err != nil {
+		return 0, err
+	}
+
+	results, assign, ret, tail := t.insertResult()
+	err = t.contextFunc(true, "Insert", "", "", results)
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.generateUUIDs(string(t.r), ret)
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.setTimestamps(string(t.r), true)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString("\t" + assign + "db.ExecContext(ctx, \"")
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.insertSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString("\", ")
+	if err != nil {
+		return 0, err
+	}
+
+	// buld the struct field stuff: skip the pk Columns and only use the fields
+	// that have corresponding columns in sqlInf
+	var j int // index into the sqlInf Columns
+	for _, v := range t.columns {
+		// skip if this column isn't in sqlInf; columns are in same order
+		if v.Name != t.sqlInf.Columns[j] {
+			continue
+		}
+		j++         // point to next column
+		if j == 1 { // if this is the first element added, don't prefix with ', '
+			_, err = t.buf.WriteString(fmt.Sprintf("%c.%s", t.r, v.fieldName))
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, v.fieldName))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = t.buf.WriteString(")\n" + tail + "}\n")
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// InsertBatchFunc generates the func for INSERTing multiple rows into the
+// table using multi-row INSERTs. The number of bytes written to the writer is
+// returned along with any error that may occur. If the table is a view, no
+// func will be generated.
+func (t *Table) InsertBatchFunc(w io.Writer) (n int64, err error) {
+	if t.IsView() {
+		return 0, nil
+	}
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(insertBatchComment, t.structName, t.name, t.batchSize()), 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(c)
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.batchFunc("InsertBatch", "")
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// batchSize returns the maximum number of rows in a multi-row INSERT: the
+// configured BatchSize, limited by the number of rows whose values fit within
+// MaxPlaceholders.
+func (t *Table) batchSize() int {
+	size := MaxPlaceholders
+	if cols := len(t.NonAutoIncrementColumnNames()); cols > 0 {
+		size = MaxPlaceholders / cols
+	}
+	if t.cfg.BatchSize > 0 && t.cfg.BatchSize < size {
+		return t.cfg.BatchSize
+	}
+	return size
+}
+
+// batchFunc writes a func, named using the table's struct name followed by
+// name, that INSERTs rows using multi-row INSERTs of, at most, batchSize rows.
+// suffix is appended to each INSERT statement.
+func (t *Table) batchFunc(name, suffix string) error {
+	t.sqlInf.Columns = t.NonAutoIncrementColumnNames()
+	var into, values bytes.Buffer
+	err := dbsql2go.InsertSQL.ExecuteTemplate(&into, "insertinto", t.sqlInf)
+	if err != nil {
+		return err
+	}
+	err = dbsql2go.InsertSQL.ExecuteTemplate(&values, "insertvalues", t.sqlInf)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("func %s%s(ctx context.Context, db ContextQuerier, rows []%s) (id, n int64, err error) {\n\tconst size = %d // the maximum number of rows per INSERT\n\tfor len(rows) > 0 {\n\t\tbatch := rows\n\t\tif len(batch) > size {\n\t\t\tbatch = batch[:size]\n\t\t}\n\t\trows = rows[len(batch):]\n\t\targs := make([]interface{}, 0, len(batch)*%d)\n\t\tfor i := range batch {\n", t.structName, name, t.structName, t.batchSize(), len(t.sqlInf.Columns)))
+	if err != nil {
+		return err
+	}
+
+	err = t.generateUUIDs("batch[i]", "id, n, err")
+	if err != nil {
+		return err
+	}
+
+	err = t.setTimestamps("batch[i]", true)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("\t\t\targs = append(args, %s)\n\t\t}\n\t\tquery := %q + strings.Repeat(%q, len(batch)-1) + %q\n", t.fieldArgs("batch[i]", t.sqlInf.Columns, false), into.String()+" ", values.String()+", ", values.String()+suffix))
+	if err != nil {
+		return err
+	}
+
+	_, err = t.buf.WriteString("\t\tres, err := db.ExecContext(ctx, query, args...)\n\t\tif err != nil {\n\t\t\treturn id, n, err\n\t\t}\n\t\tif id == 0 {\n\t\t\tid, err = res.LastInsertId()\n\t\t\tif err != nil {\n\t\t\t\treturn id, n, err\n\t\t\t}\n\t\t}\n\t\tcnt, err := res.RowsAffected()\n\t\tif err != nil {\n\t\t\treturn id, n, err\n\t\t}\n\t\tn += cnt\n\t}\n\treturn id, n, nil\n}\n")
+	return err
+}
+
+// generateUUIDs writes the code that sets the UUID primary key columns of v,
+// a variable of the table's struct type, using uuid.Generate, when they
+// aren't set. If an error occurs, the generated code returns ret. If the
+// table doesn't have a UUID primary key, nothing is written.
+func (t *Table) generateUUIDs(v, ret string) error {
+	pk := t.key()
+	if pk == nil {
+		return nil
+	}
+	for _, col := range t.columns {
+		if col.IsNullable == "YES" || !pk.HasColumn(col.Name) {
+			continue
+		}
+		typ := col.uuidType(&t.cfg)
+		if typ == "" {
+			continue
+		}
+		_, err := t.buf.WriteString(fmt.Sprintf("\tif %s.%s == (%s{}) {\n\t\tnewID, err := uuid.Generate()\n\t\tif err != nil {\n\t\t\treturn %s\n\t\t}\n\t\t%s.%s = %s(newID)\n\t}\n", v, col.fieldName, typ, ret, v, col.fieldName, typ))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// autoIncrementColumn returns the table's auto-increment column, if it has
+// one. A NULL auto-increment column isn't used as its field can't be set
+// using a conversion of the generated ID.
+func (t *Table) autoIncrementColumn() *Column {
+	for i, col := range t.columns {
+		if col.Extra == "auto_increment" && col.IsNullable == "NO" {
+			return &t.columns[i]
+		}
+	}
+	return nil
+}
+
+// insertComment returns the comment of the Insert method, which depends on
+// whether or not it returns the INSERT's sql.Result, sets timestamps, and sets
+// the struct's auto-increment field.
+func (t *Table) insertComment() string {
+	id := t.timestampsComment("INSERT", true)
+	if col := t.autoIncrementColumn(); col != nil {
+		id += fmt.Sprintf(insertIDComment, col.fieldName, col.Name)
+	}
+	if t.cfg.InsertResult {
+		return fmt.Sprintf(insertResultComment, t.name, id)
+	}
+	return fmt.Sprintf(insertPKComment, t.name, id)
+}
+
+// insertResult returns the parts of an Insert method that depend on whether
+// or not it returns the INSERT's sql.Result: the method's results, the
+// assignment of the Exec's results, what is returned when an error occurs, and
+// the code that follows the Exec, which sets the struct's auto-increment
+// field, if it has one, using the ID generated by the INSERT and unmarks the
+// columns changed by the setters.
+func (t *Table) insertResult() (results, assign, ret, tail string) {
+	results, assign, ret = "error", "res, err := ", "err"
+	ok := "nil"
+	if t.cfg.InsertResult {
+		results, ret, ok = "(sql.Result, error)", "nil, err", "res, nil"
+	}
+	col := t.autoIncrementColumn()
+	reset := t.resetChanged()
+	if col == nil && !t.cfg.InsertResult {
+		assign = "_, err := "
+		if reset == "" {
+			return results, assign, ret, "\treturn err\n"
+		}
+	}
+	tail = fmt.Sprintf("\tif err != nil {\n\t\treturn %s\n\t}\n", ret)
+	if col != nil {
+		id := "id"
+		if typ := col.goType(&t.cfg); typ != "int64" {
+			id = typ + "(id)"
+		}
+		tail += fmt.Sprintf("\tid, err := res.LastInsertId()\n\tif err != nil {\n\t\treturn %s\n\t}\n\t%c.%s = %s\n", ret, t.r, col.fieldName, id)
+	}
+	return results, assign, ret, tail + reset + "\treturn " + ok + "\n"
+}
+
+// insertSQL returns an INSERT statement for the table.
+func (t *Table) insertSQL() error {
+	// set up the relevant infor for the SQL generation; Table is already set.
+	t.sqlInf.Columns = t.NonAutoIncrementColumnNames()
+
+	err := dbsql2go.InsertSQL.Execute(&t.buf, t.sqlInf)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateMethod generates the method for updatating a table row using its PK
+// and writes it to the writer. The number of bytes written is returned. If an
+// error occurs that is returned along with the number of bytes written. If the
+// table does not have a key, the UpdateExact method is generated instead.
+// Views don't have an update method, which is not an error.
+func (t *Table) UpdateMethod(w io.Writer) (n int64, err error) {
+	if t.IsView() {
+		// nothing to do
+		return 0, nil
+	}
+	if t.key() == nil {
+		err = t.updateExact()
+		if err != nil {
+			return 0, err
+		}
+		return t.buf.WriteTo(w)
+	}
+
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	// write the comment
+	cmt := fmt.Sprintf(updatePKComment, t.name) + t.timestampsComment("UPDATE", false)
+	if col, _ := t.versionColumn(); col != nil {
+		cmt += fmt.Sprintf(versionComment, col.Name, "UPDATEd")
+	}
+	c, err := dbsql2go.StringToComments(cmt, 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(c)
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.contextFunc(true, "Update", "", "", "(n int64, err error)")
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.setTimestamps(string(t.r), false)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString("\tres, err := db.ExecContext(ctx, \"")
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.updateSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString("\", ")
+	if err != nil {
+		return 0, err
+	}
+
+	/*
+		_, err = w.Write([]byte(""))
+		if err != nil {
+			return err
+		}
+	*/
+	var j int //keep track of what's been added
+
+	// buld the struct field stuff
+	for _, v := range t.columns {
+		var b bool
+		// if the column isn't in the list of columns added to the query, skip it
+		for _, col := range t.sqlInf.Columns {
+			if v.Name == col {
+				b = true
+				break
+			}
+		}
+		if !b {
+			continue
+		}
+		j++
+		if j == 1 {
+			_, err = t.buf.WriteString(fmt.Sprintf("%c.%s", t.r, v.fieldName))
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, v.fieldName))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, v := range t.key().Fields {
+		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, v))
+		if err != nil {
+			return 0, err
+		}
+	}
+	if col, _ := t.versionColumn(); col != nil {
+		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, col.fieldName))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = t.buf.WriteString(")\n\tif err != nil {\n\t\treturn 0, err\n\t}\n" + t.versionResult(true, fmt.Sprintf("db.QueryRowContext(ctx, %q, %s)", t.versionSelectSQL(), t.fieldArgs(string(t.r), t.key().Columns, false)), t.resetChanged()) + "}")
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// updateSQLPK returns an UPDATE statement for the table that updates all
+// non-auto increment columns using the table's pk in the WHERE clause. If
+// the table does not have a PK, no UPDATE statement will be generated and a
+// nil will be returned as this is not an error state.
+func (t *Table) updateSQL() error {
+	// set up the relevant infor for the SQL generation; Table is already set.
+	t.sqlInf.Columns = t.updateColumnNames()
+	t.sqlInf.WhereColumns = t.versionWhere()
+	t.sqlInf.IncrementColumns = nil
+	if col, ts := t.versionColumn(); col != nil && !ts {
+		t.sqlInf.IncrementColumns = []string{col.Name}
+	}
+	err := dbsql2go.UpdateSQL.Execute(&t.buf, t.sqlInf)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// versionColumn returns the table's column that is used for optimistic
+// locking, if there is one, and whether or not it is a timestamp that is set
+// ON UPDATE CURRENT_TIMESTAMP instead of an integer that is incremented by
+// each UPDATE. Only tables with a primary key have a version column.
+func (t *Table) versionColumn() (col *Column, timestamp bool) {
+	if t.key() == nil || t.IsView() {
+		return nil, false
+	}
+	for i, c := range t.columns {
+		if c.IsNullable != "NO" || t.key().HasColumn(c.Name) {
+			continue
+		}
+		switch c.DataType {
+		case "tinyint", "smallint", "mediumint", "int", "bigint":
+			if t.cfg.IsVersionColumn(t.name, c.Name) {
+				return &t.columns[i], false
+			}
+		}
+	}
+	if !t.cfg.VersionTimestamp {
+		return nil, false
+	}
+	for i, c := range t.columns {
+		if c.IsNullable == "NO" && strings.Contains(strings.ToLower(c.Extra), "on update current_timestamp") {
+			return &t.columns[i], true
+		}
+	}
+	return nil, false
+}
+
+// versionWhere returns the columns of the WHERE clause of the UPDATE and the
+// DELETE: the primary key's columns and the version column, if there is one.
+func (t *Table) versionWhere() []string {
+	// a new slice is used as the pk's Columns mustn't be appended to.
+	cols := append([]string(nil), t.key().Columns...)
+	if col, _ := t.versionColumn(); col != nil {
+		cols = append(cols, col.Name)
+	}
+	return cols
+}
+
+// updateColumnNames returns the columns that are set by the UPDATE: the
+// non-auto increment columns except for the version column, which is either
+// incremented or, if it's a timestamp, set by the server.
+func (t *Table) updateColumnNames() []string {
+	col, _ := t.versionColumn()
+	if col == nil {
+		return t.NonAutoIncrementColumnNames()
+	}
+	var cols []string
+	for _, v := range t.NonAutoIncrementColumnNames() {
+		if v != col.Name {
+			cols = append(cols, v)
+		}
+	}
+	return cols
+}
+
+// versionSelectSQL returns the SELECT of a row's version using its primary
+// key. If the table doesn't have a version column, an empty string is
+// returned.
+func (t *Table) versionSelectSQL() string {
+	col, _ := t.versionColumn()
+	if col == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	dbsql2go.SelectSQL.Execute(&buf, dbsql2go.TableSQL{Table: t.name, Columns: []string{col.Name}, WhereColumns: t.key().Columns})
+	return buf.String()
+}
+
+// versionResult returns the code that returns the number of rows affected,
+// from res, by the UPDATE, if update is true, or the DELETE of a row. If the
+// table has a version column and the row wasn't affected, a StaleObjectErr
+// is returned; an UPDATE also updates the struct's version. As the server sets
+// a timestamp version, it is SELECTed using row, an expression that returns
+// the *sql.Row. set, if any, is the code that updates the struct once the
+// statement has succeeded.
+func (t *Table) versionResult(update bool, row, set string) string {
+	col, ts := t.versionColumn()
+	s := "\tn, err = res.RowsAffected()\n\tif err != nil {\n\t\treturn 0, err\n\t}\n"
+	if col == nil {
+		if set == "" {
+			return "\treturn res.RowsAffected()\n"
+		}
+		return s + set + "\treturn n, nil\n"
+	}
+	stale := fmt.Sprintf("\t\treturn 0, StaleObjectErr{Table: %q}\n", t.name)
+	if !update || !ts {
+		s += fmt.Sprintf("\tif n == 0 {\n%s\t}\n", stale)
+		if update {
+			s += fmt.Sprintf("\t%c.%s++\n", t.r, col.fieldName)
+		}
+		return s + set + "\treturn n, nil\n"
+	}
+	// the server only sets the timestamp when one of the row's values is
+	// changed, so a row that wasn't affected may be unchanged instead of stale.
+	cur, _, _ := col.value(string(t.r), &t.cfg)
+	v := strings.Replace(cur, string(t.r)+"."+col.fieldName, "ts", 1)
+	s += fmt.Sprintf("\t// the timestamp is only set when one of the row's values is changed.\n\tvar ts %s\n\terr = %s.Scan(&ts)\n\tif err == sql.ErrNoRows {\n%s\t}\n\tif err != nil {\n\t\treturn 0, err\n\t}\n", col.goType(&t.cfg), row, stale)
+	s += fmt.Sprintf("\tif n == 0 && !%s.Equal(%s) {\n%s\t}\n\t%c.%s = ts\n%s\treturn n, nil\n", v, cur, stale, t.r, col.fieldName, set)
+	return s
+}
+
+// timestampColumn returns the first of the table's DATETIME and TIMESTAMP
+// columns that match reports as true that the generated code needs to set:
+// columns that have a default or are set ON UPDATE are set by the server.
+// Columns whose type is overridden aren't used. Views don't have timestamp
+// columns.
+func (t *Table) timestampColumn(match func(table, column string) bool) *Column {
+	if t.IsView() {
+		return nil
+	}
+	for i, c := range t.columns {
+		if !match(t.name, c.Name) || c.Default.Valid || strings.Contains(strings.ToLower(c.Extra), "on update") {
+			continue
+		}
+		if _, ok := t.cfg.ColumnType(t.name, c.Name); ok {
+			continue
+		}
+		switch c.DataType {
+		case "datetime", "timestamp":
+			return &t.columns[i]
+		}
+	}
+	return nil
+}
+
+// createdColumn returns the column that holds the time that a row was
+// INSERTed, if the generated code sets it.
+func (t *Table) createdColumn() *Column {
+	return t.timestampColumn(t.cfg.IsCreatedColumn)
+}
+
+// updatedColumn returns the column that holds the time that a row was last
+// INSERTed or UPDATEd, if the generated code sets it.
+func (t *Table) updatedColumn() *Column {
+	return t.timestampColumn(t.cfg.IsUpdatedColumn)
+}
+
+// clock returns the name of the Clock that the generated code gets the
+// current time from.
+func (t *Table) clock() string {
+	if t.cfg.Clock == "" {
+		return "DefaultClock"
+	}
+	return t.cfg.Clock
+}
+
+// now returns the expression that the generated code gets the current time
+// with.
+func (t *Table) now() string {
+	return t.clock() + ".Now()"
+}
+
+// setTimestamps writes the code that sets the timestamp columns of v, a
+// variable of the table's struct type, to the current time: the created and
+// updated columns, if insert is true, otherwise just the updated column. If
+// the table doesn't have any timestamp columns to set, nothing is written.
+func (t *Table) setTimestamps(v string, insert bool) error {
+	cols := []*Column{t.updatedColumn()}
+	if insert {
+		cols = append([]*Column{t.createdColumn()}, cols...)
+	}
+	var set string
+	for _, col := range cols {
+		if col != nil {
+			set += fmt.Sprintf("\t%s.%s = %s\n", v, col.fieldName, col.timeField("now", &t.cfg))
+		}
+	}
+	if set == "" {
+		return nil
+	}
+	_, err := t.buf.WriteString(fmt.Sprintf("\tnow := %s\n%s", t.now(), set))
+	return err
+}
+
+// timestampsComment returns the sentence, for the comment of the method that
+// does the stmt, that explains which timestamp columns are set: the created
+// and updated columns, if insert is true, otherwise just the updated column.
+// If none are set, an empty string is returned.
+func (t *Table) timestampsComment(stmt string, insert bool) string {
+	var names []string
+	if col := t.createdColumn(); insert && col != nil {
+		names = append(names, col.Name)
+	}
+	if col := t.updatedColumn(); col != nil {
+		names = append(names, col.Name)
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(timestampsComment, stmt, names[0]+" is", t.clock())
+	}
+	return fmt.Sprintf(timestampsComment, stmt, strings.Join(names, " and ")+" are", t.clock())
+}
+
+// softDeleteColumn returns the table's soft delete column, if it has one:
+// the first NULL DATETIME or TIMESTAMP column whose name matches one of the
+// SoftDeleteColumns patterns. Columns whose type is overridden aren't used as
+// the generated code needs to set them. Views don't have a soft delete column.
+func (t *Table) softDeleteColumn() *Column {
+	if t.IsView() {
+		return nil
+	}
+	for i, c := range t.columns {
+		if c.IsNullable != "YES" || !t.cfg.IsSoftDeleteColumn(t.name, c.Name) {
+			continue
+		}
+		if _, ok := t.cfg.ColumnType(t.name, c.Name); ok {
+			continue
+		}
+		switch c.DataType {
+		case "datetime", "timestamp":
+			return &t.columns[i]
+		}
+	}
+	return nil
+}
+
+// whereNotDeleted returns the columns that must be NULL for the table's
+// SELECTs to skip the soft deleted rows: the soft delete column, if there is
+// one, unless withDeleted is true.
+func (t *Table) whereNotDeleted(withDeleted bool) []string {
+	col := t.softDeleteColumn()
+	if col == nil || withDeleted {
+		return nil
+	}
+	return []string{col.Name}
+}
+
+// deletedSuffixes returns the suffixes of the names of the variants of the
+// table's SELECTs: the SELECT, which skips the soft deleted rows, and, if the
+// table has a soft delete column, its WithDeleted variant, which doesn't.
+func (t *Table) deletedSuffixes() []string {
+	if t.softDeleteColumn() == nil {
+		return []string{""}
+	}
+	return []string{"", "WithDeleted"}
+}
+
+// notDeletedComment returns the sentence, for the comment of a SELECT, that
+// explains that soft deleted rows aren't SELECTed, followed by unless, if
+// any. If the table doesn't have a soft delete column, an empty string is
+// returned.
+func (t *Table) notDeletedComment(unless string) string {
+	col := t.softDeleteColumn()
+	if col == nil {
+		return ""
+	}
+	if unless != "" {
+		unless = " unless " + unless
+	}
+	return fmt.Sprintf(notDeletedComment, col.Name, unless)
+}
+
+// preparedStmt is a statement in a table's Stmts: the name of its field and the
+// func that writes its SQL.
+type preparedStmt struct {
+	name string
+	sql  func() error
+}
+
+// PreparedStmts generates the table's prepared statements: the Stmts type
+// that holds the statements, the func that prepares them, and a method for
+// each of the table's operations that uses the prepared statement. The number
+// of bytes written is returned. Views don't have prepared statements so
+// nothing is written for them.
+func (t *Table) PreparedStmts(w io.Writer) (n int64, err error) {
+	if t.IsView() {
+		return 0, nil
+	}
+	// the statements, in the order that they are prepared.
+	var stmts []preparedStmt
+	if t.key() != nil {
+		del := t.deleteSQLPK
+		if t.softDeleteColumn() != nil {
+			del = t.softDeleteSQL
+		}
+		stmts = append(stmts, preparedStmt{"selectPK", func() error { return t.selectSQLPK(false) }}, preparedStmt{"delete", del})
+	}
+	stmts = append(stmts, preparedStmt{"insert", t.insertSQL})
+	if t.key() != nil {
+		stmts = append(stmts, preparedStmt{"update", t.updateSQL})
+		// a timestamp version is SELECTed after the UPDATE.
+		if _, ts := t.versionColumn(); ts {
+			stmts = append(stmts, preparedStmt{"version", func() error {
+				_, err := t.buf.WriteString(t.versionSelectSQL())
+				return err
+			}})
+		}
+		for _, v := range []struct{ title, start, end string }{
+			{dbsql2go.TitleExclusive, ">", "<"},
+			{dbsql2go.TitleInclusive, ">=", "<="},
+		} {
+			start, end := v.start, v.end
+			stmts = append(stmts, preparedStmt{"selectInRange" + v.title, func() error {
+				t.sqlInf.Columns = t.ColumnNames()
+				t.setInRangeWhere()
+				t.setInRangeOps(start, end)
+				t.sqlInf.WhereNull = t.whereNotDeleted(false)
+				return dbsql2go.SelectAndOrSQL.Execute(&t.buf, t.sqlInf)
+			}})
+		}
+	}
+
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	// the type
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(stmtsComment, t.structName, t.name, t.structName), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("%stype %sStmts struct {\n", c, t.structName))
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range stmts {
+		_, err = t.buf.WriteString(fmt.Sprintf("\t%s *sql.Stmt\n", v.name))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// prepare the statements
+	c, err = dbsql2go.StringToComments(fmt.Sprintf(prepareStmtsComment, t.structName, t.name), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("}\n\n%sfunc Prepare%sStmts(ctx context.Context, db Preparer) (*%sStmts, error) {\n\tvar stmts %sStmts\n\tvar err error\n", c, t.structName, t.structName, t.structName))
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range stmts {
+		_, err = t.buf.WriteString(fmt.Sprintf("\tstmts.%s, err = db.PrepareContext(ctx, \"", v.name))
+		if err != nil {
+			return 0, err
+		}
+		err = v.sql()
+		if err != nil {
+			return 0, err
+		}
+		_, err = t.buf.WriteString("\")\n\tif err != nil {\n\t\tstmts.Close()\n\t\treturn nil, err\n\t}\n")
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// close them
+	c, err = dbsql2go.StringToComments(stmtsCloseComment, 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\treturn &stmts, nil\n}\n\n%sfunc (stmts *%sStmts) Close() error {\n\tvar err error\n\tfor _, stmt := range []*sql.Stmt{", c, t.structName))
+	if err != nil {
+		return 0, err
+	}
+	for i, v := range stmts {
+		if i > 0 {
+			err = t.buf.WriteByte(',')
+			if err != nil {
+				return 0, err
+			}
+		}
+		_, err = t.buf.WriteString("stmts." + v.name)
+		if err != nil {
+			return 0, err
+		}
+	}
+	_, err = t.buf.WriteString("} {\n\t\tif stmt == nil {\n\t\t\tcontinue\n\t\t}\n\t\tcerr := stmt.Close()\n\t\tif cerr != nil && err == nil {\n\t\t\terr = cerr\n\t\t}\n\t}\n\treturn err\n}\n")
+	if err != nil {
+		return 0, err
+	}
+
+	// the operations
+	for _, v := range stmts {
+		if v.name == "version" { // only used by Update
+			continue
+		}
+		err = t.stmtsMethod(v.name)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// stmtsMethod writes the Stmts method for the operation that uses the named
+// prepared statement.
+func (t *Table) stmtsMethod(name string) error {
+	var method, sig, body string
+	recv := fmt.Sprintf("%c *%s", t.r, t.structName)
+	pk := t.key()
+	switch name {
+	case "selectPK":
+		method = "Select"
+		sig = fmt.Sprintf("(ctx context.Context, %s) error", recv)
+		body = fmt.Sprintf("\terr := stmts.selectPK.QueryRowContext(ctx, %s).Scan(%s)\n\tif err != nil {\n\t\treturn err\n\t}\n%s\treturn nil\n}\n", t.fieldArgs(string(t.r), pk.Columns, false), t.fieldArgs(string(t.r), t.ColumnNames(), true), t.resetChanged())
+	case "delete":
+		method = "Delete"
+		sig = fmt.Sprintf("(ctx context.Context, %s) (n int64, err error)", recv)
+		body = fmt.Sprintf("\tres, err := stmts.delete.ExecContext(ctx, %s)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n%s}\n", t.fieldArgs(string(t.r), t.versionWhere(), false), t.versionResult(false, "", ""))
+		if col := t.softDeleteColumn(); col != nil {
+			row := fmt.Sprintf("stmts.version.QueryRowContext(ctx, %s)", t.fieldArgs(string(t.r), pk.Columns, false))
+			body = fmt.Sprintf("\tnow := %s\n\tres, err := stmts.delete.ExecContext(ctx, now, %s)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n%s}\n", t.now(), t.fieldArgs(string(t.r), t.versionWhere(), false), t.versionResult(true, row, fmt.Sprintf("\t%c.%s = %s\n", t.r, col.fieldName, col.timeField("now", &t.cfg))))
+		}
+	case "insert":
+		method = "Insert"
+		results, assign, _, tail := t.insertResult()
+		sig = fmt.Sprintf("(ctx context.Context, %s) %s", recv, results)
+		body = fmt.Sprintf("\t%sstmts.insert.ExecContext(ctx, %s)\n%s}\n", assign, t.fieldArgs(string(t.r), t.NonAutoIncrementColumnNames(), false), tail)
+	case "update":
+		method = "Update"
+		sig = fmt.Sprintf("(ctx context.Context, %s) (n int64, err error)", recv)
+		body = fmt.Sprintf("\tres, err := stmts.update.ExecContext(ctx, %s, %s)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n%s}\n", t.fieldArgs(string(t.r), t.updateColumnNames(), false), t.fieldArgs(string(t.r), t.versionWhere(), false), t.versionResult(true, fmt.Sprintf("stmts.version.QueryRowContext(ctx, %s)", t.fieldArgs(string(t.r), pk.Columns, false)), t.resetChanged()))
+	default: // the in range selects
+		method = strings.ToUpper(name[:1]) + name[1:]
+		params, _, sqlArgs := t.inRangeParams()
+		sig = fmt.Sprintf("(ctx context.Context, %s) (results []%s, err error)", params, t.structName)
+		body = fmt.Sprintf("\trows, err := stmts.%s.QueryContext(ctx, %s)", name, sqlArgs)
+	}
+
+	// the Select, Delete, Insert, and Update methods are on the table's struct; the rest are funcs.
+	orig := fmt.Sprintf("%s's %sContext method", t.structName, method)
+	if strings.HasPrefix(name, "selectInRange") {
+		orig = t.structName + method + "Context"
+	}
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(stmtsMethodComment, method, orig), 80)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc (stmts *%sStmts) %s%s {\n", c, t.structName, method, sig))
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "insert":
+		_, _, ret, _ := t.insertResult()
+		err = t.generateUUIDs(string(t.r), ret)
+		if err != nil {
+			return err
+		}
+		err = t.setTimestamps(string(t.r), true)
+	case "update":
+		err = t.setTimestamps(string(t.r), false)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString(body)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(name, "selectInRange") {
+		return nil
+	}
+	err = t.scanRows(&t.buf)
+	if err != nil {
+		return err
+	}
+
+	// the Rows variant
+	c, err = dbsql2go.StringToComments(fmt.Sprintf(stmtsMethodComment, method+"Rows", t.structName+method+"Rows"), 80)
+	if err != nil {
+		return err
+	}
+	params, _, sqlArgs := t.inRangeParams()
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc (stmts *%sStmts) %sRows(ctx context.Context, %s) (*%sRows, error) {\n\trows, err := stmts.%s.QueryContext(ctx, %s)", c, t.structName, method, params, t.structName, name, sqlArgs))
+	if err != nil {
+		return err
+	}
+	return t.rowsFunc(&t.buf)
+}
+
+// contextFunc writes a func that calls its Context variant using
+// context.Background() followed by the comment and the signature of the
+// Context variant; the body of the Context variant is left to the caller. If
+// method is true, the funcs are methods on the table's struct. The func
+// accepts a Querier and its Context variant a ContextQuerier. params are the
+// func's other parameters and args are the corresponding arguments that are
+// passed to the Context variant.
+func (t *Table) contextFunc(method bool, name, params, args, results string) error {
+	var recv, call string
+	if method {
+		recv = fmt.Sprintf("(%c *%s) ", t.r, t.structName)
+		call = fmt.Sprintf("%c.", t.r)
+	}
+	if params != "" {
+		params = ", " + params
+		args = ", " + args
+	}
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(contextComment, name), 80)
+	if err != nil {
+		return err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("func %s%s(db Querier%s) %s {\n\treturn %s%sContext(context.Background(), db%s)\n}\n\n%sfunc %s%sContext(ctx context.Context, db ContextQuerier%s) %s {\n", recv, name, params, results, call, name, args, c, recv, name, params, results))
+	return err
+}
+
+// updatableColumns returns the columns that UpdateColumns can UPDATE: the
+// columns that aren't part of the primary key, auto-increment columns, the
+// version column, or the updated column, which is always UPDATEd. Only tables
+// with a primary key have updatable columns.
+func (t *Table) updatableColumns() []Column {
+	if t.key() == nil || t.IsView() {
+		return nil
+	}
+	version, _ := t.versionColumn()
+	updated := t.updatedColumn()
+	var cols []Column
+	for _, col := range t.columns {
+		if col.Extra == "auto_increment" || t.key().HasColumn(col.Name) || version != nil && col.Name == version.Name || updated != nil && col.Name == updated.Name {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// ChangeMethods generates the methods that track the changes made to a row,
+// the setters, and the methods that UPDATE only some of the row's columns:
+// UpdateColumns and UpdateChanged. Only tables with updatable columns have
+// them. The number of bytes written is returned along with any error that
+// occurs.
+func (t *Table) ChangeMethods(w io.Writer) (n int64, err error) {
+	cols := t.updatableColumns()
+	if len(cols) == 0 {
+		return 0, nil
+	}
+	changed, err := t.changedMethod()
+	if err != nil {
+		return 0, err
+	}
+	t.buf.Reset()
+
+	// Changed
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(changedComment, changed), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc (%c *%s) %s() []%sColumn {\n\tvar cols []%sColumn\n\tfor i, changed := range %c.changed {\n\t\tif changed {\n\t\t\tcols = append(cols, %sColumn(i))\n\t\t}\n\t}\n\treturn cols\n}\n", c, t.r, t.structName, changed, t.structName, t.structName, t.r, t.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	// the setters
+	for _, col := range cols {
+		c, err = dbsql2go.StringToComments(fmt.Sprintf(setterComment, col.fieldName, col.fieldName, col.Name), 80)
+		if err != nil {
+			return 0, err
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc (%c *%s) Set%s(v %s) {\n\t%c.%s = v\n\t%c.changed[%sColumn%s] = true\n}\n", c, t.r, t.structName, col.fieldName, col.goType(&t.cfg), t.r, col.fieldName, t.r, t.structName, col.fieldName))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// UpdateColumns
+	cmt := fmt.Sprintf(updateColumnsComment, t.name) + t.timestampsComment("UPDATE", false)
+	if col, _ := t.versionColumn(); col != nil {
+		cmt += fmt.Sprintf(versionComment, col.Name, "UPDATEd")
+	}
+	c, err = dbsql2go.StringToComments(cmt, 80)
+	if err != nil {
+		return 0, err
+	}
+	// the WHERE's args and the updated column's follow the columns' args.
+	nargs := len(t.versionWhere())
+	if t.updatedColumn() != nil {
+		nargs++
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc (%c *%s) UpdateColumns(ctx context.Context, db ContextQuerier, cols ...%sColumn) (n int64, err error) {\n\tif len(cols) == 0 {\n\t\treturn 0, nil\n\t}\n\tset := make([]string, 0, len(cols))\n\targs := make([]interface{}, 0, len(cols)+%d)\n\tfor _, col := range cols {\n\t\tswitch col {\n", c, t.r, t.structName, t.structName, nargs))
+	if err != nil {
+		return 0, err
+	}
+	for _, col := range cols {
+		name, err := sqlIdent(col.Name)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", t.name, err)
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf("\t\tcase %sColumn%s:\n\t\t\tset = append(set, %q)\n\t\t\targs = append(args, %c.%s)\n", t.structName, col.fieldName, name+" = ?", t.r, col.fieldName))
+		if err != nil {
+			return 0, err
+		}
+	}
+	// like the SET, the rest of the UPDATE quotes the names that need it.
+	table, err := sqlIdent(t.name)
+	if err != nil {
+		return 0, err
+	}
+	whereCols := t.versionWhere()
+	where := make([]string, len(whereCols))
+	for i, v := range whereCols {
+		name, err := sqlIdent(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", t.name, err)
+		}
+		where[i] = name + " = ?"
+	}
+	// the updated column is set along with the columns.
+	var updated string
+	if col := t.updatedColumn(); col != nil {
+		name, err := sqlIdent(col.Name)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", t.name, err)
+		}
+		updated = fmt.Sprintf("\tnow := %s\n\t%c.%s = %s\n\tset = append(set, %q)\n\targs = append(args, %c.%s)\n", t.now(), t.r, col.fieldName, col.timeField("now", &t.cfg), name+" = ?", t.r, col.fieldName)
+	}
+	// an integer version is incremented along with the columns.
+	var incr string
+	if col, ts := t.versionColumn(); col != nil && !ts {
+		name, err := sqlIdent(col.Name)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", t.name, err)
+		}
+		incr = fmt.Sprintf(", %s = %s + 1", name, name)
+	}
+	row := fmt.Sprintf("db.QueryRowContext(ctx, %q, %s)", t.versionSelectSQL(), t.fieldArgs(string(t.r), t.key().Columns, false))
+	reset := fmt.Sprintf("\tfor _, col := range cols {\n\t\t%c.changed[col] = false\n\t}\n", t.r)
+	_, err = t.buf.WriteString(fmt.Sprintf("\t\tdefault:\n\t\t\treturn 0, InvalidColumnErr{Table: %q, Column: int(col)}\n\t\t}\n\t}\n%s\tres, err := db.ExecContext(ctx, \"UPDATE %s SET \"+strings.Join(set, \", \")+\"%s WHERE %s\", append(args, %s)...)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n%s}\n", t.name, updated, table, incr, strings.Join(where, " AND "), t.fieldArgs(string(t.r), whereCols, false), t.versionResult(true, row, reset)))
+	if err != nil {
+		return 0, err
+	}
+
+	// UpdateChanged
+	c, err = dbsql2go.StringToComments(fmt.Sprintf(updateChangedComment, changed, t.name), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc (%c *%s) UpdateChanged(ctx context.Context, db ContextQuerier) (n int64, err error) {\n\treturn %c.UpdateColumns(ctx, db, %c.%s()...)\n}\n", c, t.r, t.structName, t.r, t.r, changed))
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// JSONMethods generates the table's JSON type, which represents the NULL
+// wrapper fields as plain values, and the MarshalJSON and UnmarshalJSON
+// methods that use it. Only tables with NULL wrapper fields have them and
+// only if the Config's JSONNulls is set. The number of bytes written is
+// returned along with any error that occurs.
+func (t *Table) JSONMethods(w io.Writer) (n int64, err error) {
+	if !t.hasJSONType() {
+		return 0, nil
+	}
+	t.buf.Reset()
+	typ := unexported(t.structName) + "JSON"
+
+	// the JSON type has the same fields, with the same tags, as the struct.
+	c, err := dbsql2go.StringToComments(fmt.Sprintf(jsonTypeComment, typ, t.structName), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%stype %s struct {\n", c, typ))
+	if err != nil {
+		return 0, err
+	}
+	for _, col := range t.columns {
+		f, _ := col.jsonType(&t.cfg)
+		if tag := t.cfg.StructTag(col.table, col.Name, col.IsNullable == "YES"); tag != "" {
+			f += " " + tag
+		}
+		_, err = t.buf.WriteString(fmt.Sprintf("\t%s %s\n", col.fieldName, f))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// MarshalJSON
+	c, err = dbsql2go.StringToComments(marshalJSONComment, 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("}\n\n%sfunc (%c %s) MarshalJSON() ([]byte, error) {\n\treturn json.Marshal(%c.toJSON())\n}\n", c, t.r, t.structName, t.r))
+	if err != nil {
+		return 0, err
+	}
+
+	// UnmarshalJSON: v starts with the struct's values so that the columns
+	// that aren't in the JSON are left unchanged.
+	c, err = dbsql2go.StringToComments(unmarshalJSONComment, 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\n%sfunc (%c *%s) UnmarshalJSON(b []byte) error {\n\tv := %c.toJSON()\n\terr := json.Unmarshal(b, &v)\n\tif err != nil {\n\t\treturn err\n\t}\n", c, t.r, t.structName, t.r))
+	if err != nil {
+		return 0, err
+	}
+	for _, col := range t.columns {
+		f := fmt.Sprintf("%c.%s", t.r, col.fieldName)
+		_, wrapped := col.jsonType(&t.cfg)
+		var s string
+		switch {
+		case !wrapped:
+			s = fmt.Sprintf("\t%s = v.%s\n", f, col.fieldName)
+		case col.IsNullable == "YES":
+			s = fmt.Sprintf("\t%s = %s{}\n\tif v.%s != nil {\n\t\t%s = %s\n\t}\n", f, col.goType(&t.cfg), col.fieldName, f, col.nullValue("*v."+col.fieldName, &t.cfg))
+		default:
+			s = fmt.Sprintf("\t%s = %s\n", f, col.nullValue("v."+col.fieldName, &t.cfg))
+		}
+		_, err = t.buf.WriteString(s)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// toJSON
+	c, err = dbsql2go.StringToComments(fmt.Sprintf(toJSONComment, typ), 80)
+	if err != nil {
+		return 0, err
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\treturn nil\n}\n\n%sfunc (%c %s) toJSON() %s {\n\tvar v %s\n", c, t.r, t.structName, typ, typ))
+	if err != nil {
+		return 0, err
+	}
+	for _, col := range t.columns {
+		_, wrapped := col.jsonType(&t.cfg)
+		expr, _, valid := col.value(string(t.r), &t.cfg)
+		var s string
+		switch {
+		case !wrapped:
+			s = fmt.Sprintf("\tv.%s = %c.%s\n", col.fieldName, t.r, col.fieldName)
+		case valid != "":
+			s = fmt.Sprintf("\tif %s {\n\t\tv.%s = &%s\n\t}\n", valid, col.fieldName, expr)
+		default:
+			s = fmt.Sprintf("\tv.%s = %s\n", col.fieldName, expr)
+		}
+		_, err = t.buf.WriteString(s)
+		if err != nil {
+			return 0, err
+		}
+	}
+	_, err = t.buf.WriteString("\treturn v\n}\n")
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// hasJSONType returns whether or not the table has a JSON type: the Config's
+// JSONNulls is set and at least one of its fields is a NULL wrapper type.
+func (t *Table) hasJSONType() bool {
+	if !t.cfg.JSONNulls {
+		return false
+	}
+	for _, col := range t.columns {
+		if _, wrapped := col.jsonType(&t.cfg); wrapped {
+			return true
+		}
+	}
+	return false
+}
+
+// changedMethod returns the name of the method that returns the columns
+// changed by the setters: Changed or, if the struct has a Changed field,
+// ChangedColumns. If one of the change tracking methods has the same name as
+// one of the struct's fields, an error is returned.
+func (t *Table) changedMethod() (string, error) {
+	fields := map[string]bool{}
+	for _, col := range t.columns {
+		fields[col.fieldName] = true
+	}
+	changed := "Changed"
+	if fields[changed] {
+		changed = "ChangedColumns"
+	}
+	names := []string{changed, "UpdateColumns", "UpdateChanged"}
+	for _, col := range t.updatableColumns() {
+		names = append(names, "Set"+col.fieldName)
+	}
+	for _, v := range names {
+		if fields[v] {
+			return "", fmt.Errorf("%s: the %s method collides with the %s field", t.name, v, v)
+		}
+	}
+	return changed, nil
+}
+
+// resetChanged returns the code that unmarks all of the columns changed by
+// the setters, once the whole row has been SELECTed, INSERTed, or UPDATEd. If
+// the table doesn't track changes, an empty string is returned.
+func (t *Table) resetChanged() string {
+	if len(t.updatableColumns()) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\t%c.changed = [%d]bool{}\n", t.r, len(t.columns))
+}
+
+// UpsertMethod generates the method for upserting the struct's data: the row
+// is INSERTed or, if it already exists, UPDATEd. The number of bytes written
+// to the writer is returned along with any error that may occur. Only tables
+// with a primary key or a unique constraint have an upsert method.
+func (t *Table) UpsertMethod(w io.Writer) (n int64, err error) {
+	if t.IsView() || !t.HasUniqueKey() {
+		return 0, nil
+	}
+
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	cmt := fmt.Sprintf(upsertComment, t.name) + t.timestampsComment("INSERT", true)
+	if col, ts := t.versionColumn(); col != nil && !ts {
+		cmt += fmt.Sprintf(upsertVersionComment, col.Name, col.Name)
+	}
+	c, err := dbsql2go.StringToComments(cmt, 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(c)
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.contextFunc(true, "Upsert", "", "", "(n int64, err error)")
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.generateUUIDs(string(t.r), "0, err")
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.setTimestamps(string(t.r), true)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString("\tres, err := db.ExecContext(ctx, \"")
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.insertSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.onDuplicateKeySQL(&t.buf)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("\", %s)\n\tif err != nil {\n\t\treturn 0, err\n\t}\n%s}\n", t.fieldArgs(string(t.r), t.sqlInf.Columns, false), t.upsertResult()))
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// upsertResult returns the code that returns the number of rows affected by
+// an upsert. As UPDATEing the existing row increments its integer version,
+// the struct's version is incremented too.
+func (t *Table) upsertResult() string {
+	col, ts := t.versionColumn()
+	if col == nil || ts {
+		return "\treturn res.RowsAffected()\n"
+	}
+	return fmt.Sprintf("\tn, err = res.RowsAffected()\n\tif err != nil {\n\t\treturn 0, err\n\t}\n\t// the existing row was UPDATEd.\n\tif n == 2 {\n\t\t%c.%s++\n\t}\n\treturn n, nil\n", t.r, col.fieldName)
+}
+
+// UpsertBatchFunc generates the func for upserting multiple rows using
+// multi-row INSERTs with ON DUPLICATE KEY UPDATE. The number of bytes written
+// to the writer is returned along with any error that may occur. Only tables
+// with a primary key or a unique constraint have an upsert batch func.
+func (t *Table) UpsertBatchFunc(w io.Writer) (n int64, err error) {
+	if t.IsView() || !t.HasUniqueKey() {
+		return 0, nil
+	}
+
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	cmt := fmt.Sprintf(upsertBatchComment, t.structName, t.name, t.batchSize())
+	if col, ts := t.versionColumn(); col != nil && !ts {
+		cmt += fmt.Sprintf(batchVersionComment, col.Name, col.Name)
+	}
+	c, err := dbsql2go.StringToComments(cmt, 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(c)
+	if err != nil {
+		return 0, err
+	}
+
+	var suffix bytes.Buffer
+	err = t.onDuplicateKeySQL(&suffix)
+	if err != nil {
+		return 0, err
+	}
+
+	err = t.batchFunc("UpsertBatch", suffix.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// onDuplicateKeySQL writes the ON DUPLICATE KEY UPDATE clause for the table's
+// upserts. The non-key columns are UPDATEd, except for those that the config
+// says to leave untouched. Like any other UPDATE, an integer version is always
+// incremented and a timestamp version is left for the server to set. If there
+// aren't any columns to UPDATE, the first key column is set to itself so that
+// an existing row is left as is.
+func (t *Table) onDuplicateKeySQL(w io.Writer) error {
+	ignore := t.cfg.UpsertIgnore[t.name]
+	t.sqlInf.UpdateColumns = t.sqlInf.UpdateColumns[:0]
+	t.sqlInf.IncrementColumns = nil
+	t.sqlInf.RowAlias = ""
+	pk := t.key()
+	created := t.createdColumn()
+	version, ts := t.versionColumn()
+	for _, col := range t.NonAutoIncrementColumnNames() {
+		if pk != nil && pk.HasColumn(col) {
+			continue
+		}
+		// the existing row keeps the time that it was created.
+		if created != nil && created.Name == col {
+			continue
+		}
+		if version != nil && version.Name == col {
+			if !ts {
+				t.sqlInf.IncrementColumns = []string{col}
+			}
+			continue
+		}
+		var skip bool
+		for _, v := range ignore {
+			if v == col {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			t.sqlInf.UpdateColumns = append(t.sqlInf.UpdateColumns, col)
+		}
+	}
+	if len(t.sqlInf.UpdateColumns) == 0 && len(t.sqlInf.IncrementColumns) == 0 {
+		col := t.uniqueKey().Columns[0]
+		_, err := fmt.Fprintf(w, " ON DUPLICATE KEY UPDATE %s = %s", col, col)
+		return err
+	}
+	if t.rowAlias {
+		t.sqlInf.RowAlias = RowAlias
+	}
+	return dbsql2go.OnDuplicateKeySQL.Execute(w, t.sqlInf)
+}
+
+// UniqueLookupFuncs generates a func, for each of the table's unique
+// constraints, that SELECTs the row whose unique columns match the func's
+// arguments. The funcs are named using the constraint's fields, e.g.
+// AbcByCode. The number of bytes written is returned along with any error
+// that occurs.
+func (t *Table) UniqueLookupFuncs(w io.Writer) (n int64, err error) {
+	names := map[string]bool{}
+	for i := range t.constraints {
+		c := &t.constraints[i]
+		if c.Type != dbsql2go.Unique {
+			continue
+		}
+		name := t.structName + "By" + strings.Join(c.Fields, "And")
+		if names[name] { // a duplicate of another constraint's columns
+			continue
+		}
+		names[name] = true
+		nn, err := t.uniqueLookupFunc(w, name, c)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// uniqueLookupFunc generates the func, name, that looks up a row using the
+// unique constraint c and, if the table has a soft delete column, its
+// WithDeleted variant.
+func (t *Table) uniqueLookupFunc(w io.Writer, name string, c *dbsql2go.Constraint) (n int64, err error) {
+	params := make([]string, 0, len(c.Columns))
+	args := make([]string, 0, len(c.Columns))
+	for _, v := range c.Columns {
+		col := t.column(v)
+		if col == nil {
+			return 0, fmt.Errorf("%s: unique constraint %s: column %s not found", t.name, c.Name, v)
+		}
+		p := paramName(v)
+		params = append(params, fmt.Sprintf("%s %s", p, col.goType(&t.cfg)))
+		args = append(args, p)
+	}
+
+	t.buf.Reset()
+	for _, suffix := range t.deletedSuffixes() {
+		err = t.buf.WriteByte(dbsql2go.LF)
+		if err != nil {
+			return 0, err
+		}
+
+		s := fmt.Sprintf(uniqueLookupComment, name, t.name, strings.Join(c.Columns, " and "), c.Name) + t.notDeletedComment("")
+		if suffix != "" {
+			s = fmt.Sprintf(withDeletedComment, name+suffix, name)
+		}
+		cmt, err := dbsql2go.StringToComments(s, 80)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = t.buf.WriteString(cmt)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = t.buf.WriteString(fmt.Sprintf("func %s%s(ctx context.Context, db ContextQuerier, %s) (*%s, error) {\n\tvar %c %s\n\terr := db.QueryRowContext(ctx, \"", name, suffix, strings.Join(params, ", "), t.structName, t.r, t.structName))
+		if err != nil {
+			return 0, err
+		}
+
+		t.sqlInf.Columns = t.ColumnNames()
+		t.sqlInf.WhereColumns = c.Columns
+		t.sqlInf.WhereNull = t.whereNotDeleted(suffix != "")
+		err = dbsql2go.SelectSQL.Execute(&t.buf, t.sqlInf)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = t.buf.WriteString(fmt.Sprintf("\", %s).Scan(%s)\n\tif err == sql.ErrNoRows {\n\t\treturn nil, NotFoundErr{Table: %q}\n\t}\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn &%c, nil\n}\n", strings.Join(args, ", "), t.fieldArgs(string(t.r), t.ColumnNames(), true), t.name, t.r))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// column returns the table's column with the name. If the table doesn't have
+// the column, nil is returned.
+func (t *Table) column(name string) *Column {
+	for i := range t.columns {
+		if t.columns[i].Name == name {
+			return &t.columns[i]
+		}
+	}
+	return nil
+}
+
+// paramName returns the name of the parameter for a column in a generated
+// func. Names that would collide with a keyword or with the ctx and db
+// parameters get an Arg suffix.
+func paramName(col string) string {
+	name := unexported(mixedcase.Exported(col))
+	if token.IsKeyword(name) || name == "ctx" || name == "db" {
+		return name + "Arg"
+	}
+	return name
+}
+
+// unexported returns the unexported form of an exported name. A leading
+// initialism is lowercased as a whole: ID becomes id, not iD, and URLPath
+// becomes urlPath.
+func unexported(name string) string {
+	// find the end of the leading run of upper case runes; if a lower case
+	// rune follows a run of more than one, the last upper case rune starts
+	// the next word.
+	i := 0
+	for i < len(name) && unicode.IsUpper(rune(name[i])) {
+		i++
+	}
+	if i > 1 && i < len(name) && unicode.IsLower(rune(name[i])) {
+		i--
+	}
+	if i == 0 {
+		i = 1
+	}
+	return strings.ToLower(name[:i]) + name[i:]
+}
+
+// reservedMethods are the names of the methods that may be generated for a
+// table's struct; foreign key accessors can't use them. The setters, e.g.
+// SetCode, are reserved by AccessorNames.
+var reservedMethods = []string{
+	"Changed", "ChangedColumns", "Delete", "DeleteContext", "DeleteExact", "DeleteExactContext",
+	"HardDelete", "HardDeleteContext", "Insert", "InsertContext", "Key", "MarshalJSON", "Restore",
+	"RestoreContext", "Select", "SelectContext", "SelectWithDeleted", "SelectWithDeletedContext",
+	"UnmarshalJSON", "Update", "UpdateChanged", "UpdateColumns", "UpdateContext", "UpdateExact",
+	"UpdateExactContext", "Upsert", "UpsertContext",
+}
+
+// ForeignKeyMethods generates the methods that navigate the table's foreign
+// keys: for each of the table's foreign keys, a method that SELECTs the
+// referenced, parent, row and, for each foreign key in the database that
+// references the table, a method that SELECTs the referencing, child, rows.
+// The methods are named using AccessorNames. If the table isn't part of a
+// DB, nothing is written. The number of bytes written is returned along with
+// any error that occurs.
+func (t *Table) ForeignKeyMethods(w io.Writer) (n int64, err error) {
+	if t.db == nil {
+		return 0, nil
+	}
+	names, err := t.AccessorNames()
+	if err != nil {
+		return 0, err
+	}
+	for _, fk := range t.db.foreignKeys() {
+		if fk.Table == t.name {
+			nn, err := t.parentMethod(w, names[fk.Table+"."+fk.Name+".parent"], fk)
+			n += nn
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	for _, fk := range t.db.foreignKeys() {
+		if fk.RefTable == t.name {
+			nn, err := t.childrenMethod(w, names[fk.Table+"."+fk.Name+".children"], fk)
+			n += nn
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// AccessorNames returns the names of the table's foreign key accessors keyed
+// by the foreign key's table and constraint names followed by either
+// ".parent" or ".children", e.g. "jkl.jkl_ibfk_1.parent".
+//
+// The parent accessor is named after the foreign key's field with its ID
+// suffix removed, e.g. CustomerID becomes Customer; if the foreign key is
+// composite or its field is only ID, the referenced table's struct name is
+// used. The children accessor is the plural of the referencing table's struct
+// name, e.g. Orders; if the foreign key references its own table or the
+// referencing table has other foreign keys to the table, the foreign key's
+// fields are appended, e.g. EmployeesByManagerID. If a name collides with one
+// of the struct's fields or methods, the foreign key's fields are appended
+// to it. Config.ParentNames and Config.ChildrenNames override the names.
+func (t *Table) AccessorNames() (map[string]string, error) {
+	names := map[string]string{}
+	if t.db == nil {
+		return names, nil
+	}
+	used := map[string]bool{}
+	for _, col := range t.columns {
+		used[col.fieldName] = true
+	}
+	for _, v := range reservedMethods {
+		used[v] = true
+	}
+	for _, col := range t.updatableColumns() {
+		used["Set"+col.fieldName] = true
+	}
+	fks := t.db.foreignKeys()
+	add := func(key, override, name, by string) error {
+		if override != "" {
+			name = override
+		} else if used[name] {
+			name += by
+		}
+		// the parent accessor's key method, e.g. CustomerKey, is also generated.
+		if used[name] || (strings.HasSuffix(key, ".parent") && used[name+"Key"]) {
+			return fmt.Errorf("%s: foreign key accessor %s collides with another field or method; set its name in the Config", t.name, name)
+		}
+		used[name] = true
+		if strings.HasSuffix(key, ".parent") {
+			used[name+"Key"] = true
+		}
+		names[key] = name
+		return nil
+	}
+	for _, fk := range fks {
+		if fk.Table != t.name {
+			continue
+		}
+		by := "By" + strings.Join(fk.Fields, "And")
+		name := mixedcase.Exported(fk.RefTable)
+		if len(fk.Fields) == 1 && len(fk.Fields[0]) > 2 && strings.HasSuffix(fk.Fields[0], "ID") {
+			name = strings.TrimSuffix(fk.Fields[0], "ID")
+		}
+		key := fk.Table + "." + fk.Name
+		err := add(key+".parent", t.cfg.ParentNames[key], name, by)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, fk := range fks {
+		if fk.RefTable != t.name {
+			continue
+		}
+		by := "By" + strings.Join(fk.Fields, "And")
+		name := plural(mixedcase.Exported(fk.Table))
+		if fk.Table == t.name {
+			name += by
+		} else {
+			for _, v := range fks {
+				if v.Table == fk.Table && v.RefTable == fk.RefTable && v.Name != fk.Name {
+					name += by
+					break
+				}
+			}
+		}
+		key := fk.Table + "." + fk.Name
+		err := add(key+".children", t.cfg.ChildrenNames[key], name, by)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// parentMethod generates the method, name, that SELECTs the row referenced by
+// the table's foreign key, fk.
+func (t *Table) parentMethod(w io.Writer, name string, fk dbsql2go.Constraint) (n int64, err error) {
+	parent := t.db.table(fk.RefTable)
+	if parent == nil {
+		return 0, fmt.Errorf("%s: foreign key %s: table %s not found", t.name, fk.Name, fk.RefTable)
+	}
+	nulls, err := t.nullConds(string(t.r), fk.Columns)
+	if err != nil {
+		return 0, err
+	}
+
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	s := fmt.Sprintf(parentComment, name, fk.RefTable, t.r, fk.Name) + parent.notDeletedComment("")
+	if len(nulls) > 0 {
+		s += parentNullComment
+	}
+	cmt, err := dbsql2go.StringToComments(s, 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(cmt)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("func (%c *%s) %s(ctx context.Context, db ContextQuerier) (*%s, error) {\n", t.r, t.structName, name, parent.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(nulls) > 0 {
+		_, err = t.buf.WriteString(fmt.Sprintf("\tif %s {\n\t\treturn nil, nil\n\t}\n", strings.Join(nulls, " || ")))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("\tvar parent %s\n\terr := db.QueryRowContext(ctx, \"", parent.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	inf := parent.sqlInf
+	inf.Columns = parent.ColumnNames()
+	inf.WhereColumns = fk.RefColumns
+	inf.WhereNull = parent.whereNotDeleted(false)
+	err = dbsql2go.SelectSQL.Execute(&t.buf, inf)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("\", %s).Scan(%s)\n\tif err == sql.ErrNoRows {\n\t\treturn nil, NotFoundErr{Table: %q}\n\t}\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn &parent, nil\n}\n", t.fieldArgs(string(t.r), fk.Columns, false), parent.fieldArgs("parent", parent.ColumnNames(), true), parent.name))
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// childrenMethod generates the method, name, that SELECTs the rows that
+// reference the table using the foreign key, fk.
+func (t *Table) childrenMethod(w io.Writer, name string, fk dbsql2go.Constraint) (n int64, err error) {
+	child := t.db.table(fk.Table)
+	if child == nil {
+		return 0, fmt.Errorf("%s: foreign key %s: table %s not found", t.name, fk.Name, fk.Table)
+	}
+	nulls, err := t.nullConds(string(t.r), fk.RefColumns)
+	if err != nil {
+		return 0, err
+	}
+
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	cmt, err := dbsql2go.StringToComments(fmt.Sprintf(childrenComment, name, fk.Table, t.r, fk.Name)+child.notDeletedComment(""), 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(cmt)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("func (%c *%s) %s(ctx context.Context, db ContextQuerier) (results []%s, err error) {\n", t.r, t.structName, name, child.structName))
+	if err != nil {
+		return 0, err
+	}
+
+	// NULL never matches, so there aren't any children.
+	if len(nulls) > 0 {
+		_, err = t.buf.WriteString(fmt.Sprintf("\tif %s {\n\t\treturn nil, nil\n\t}\n", strings.Join(nulls, " || ")))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = t.buf.WriteString("\trows, err := db.QueryContext(ctx, \"")
+	if err != nil {
+		return 0, err
+	}
+
+	inf := child.sqlInf
+	inf.Columns = child.ColumnNames()
+	inf.WhereColumns = fk.Columns
+	inf.WhereNull = child.whereNotDeleted(false)
+	err = dbsql2go.SelectSQL.Execute(&t.buf, inf)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("\", %s)", t.fieldArgs(string(t.r), fk.RefColumns, false)))
+	if err != nil {
+		return 0, err
+	}
+
+	err = child.scanRows(&t.buf)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.buf.WriteTo(w)
+}
+
+// nullConds returns the conditions that check whether the fields of v, a
+// variable of the table's struct type, for the nullable columns in cols are
+// NULL. Columns whose type is overridden aren't checked.
+func (t *Table) nullConds(v string, cols []string) ([]string, error) {
+	var conds []string
+	for _, name := range cols {
+		col := t.column(name)
+		if col == nil {
+			return nil, fmt.Errorf("%s: column %s not found", t.name, name)
+		}
+		_, _, valid := col.value(v, &t.cfg)
+		if valid == "" {
+			continue
+		}
+		if strings.HasSuffix(valid, " != nil") {
+			conds = append(conds, strings.TrimSuffix(valid, " != nil")+" == nil")
+			continue
+		}
+		conds = append(conds, "!"+valid)
+	}
+	return conds, nil
+}
+
+// keyTypeName returns the name of the key type for the table's cols: the
+// struct name followed by Key if cols are the primary key's columns, e.g.
+// AbcKey; otherwise the fields of the columns are included, e.g.
+// AbcCodeKey.
+func (t *Table) keyTypeName(cols []string) string {
+	if t.isPK(cols) {
+		return t.structName + "Key"
+	}
+	return t.structName + t.keyFields(cols) + "Key"
+}
+
+// keyMethodName returns the name of the method that returns the key for the
+// table's cols: Key if cols are the primary key's columns; otherwise the
+// fields of the columns followed by Key, e.g. CodeKey.
+func (t *Table) keyMethodName(cols []string) string {
+	if t.isPK(cols) {
+		return "Key"
+	}
+	return t.keyFields(cols) + "Key"
+}
+
+// keyFields returns the fields of the cols joined by And.
+func (t *Table) keyFields(cols []string) string {
+	fields := make([]string, 0, len(cols))
+	for _, v := range cols {
+		if col := t.column(v); col != nil {
+			fields = append(fields, col.fieldName)
+		}
+	}
+	return strings.Join(fields, "And")
+}
+
+// isPK returns whether or not cols are the table's primary key columns.
+func (t *Table) isPK(cols []string) bool {
+	if t.key() == nil || len(t.key().Columns) != len(cols) {
+		return false
+	}
+	for i, v := range t.key().Columns {
+		if cols[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// keys returns the sets of columns of the table that need a key type: the
+// primary key's columns and the columns that other tables' foreign keys
+// reference.
+func (t *Table) keys() [][]string {
+	var keys [][]string
+	seen := map[string]bool{}
+	if t.key() != nil {
+		keys = append(keys, t.key().Columns)
+		seen[strings.Join(t.key().Columns, ",")] = true
+	}
+	if t.db == nil {
+		return keys
+	}
+	for _, fk := range t.db.foreignKeys() {
+		if fk.RefTable != t.name || seen[strings.Join(fk.RefColumns, ",")] {
+			continue
+		}
+		seen[strings.Join(fk.RefColumns, ",")] = true
+		keys = append(keys, fk.RefColumns)
+	}
+	return keys
+}
+
+// KeyTypes generates the table's key types, which are comparable and can be
+// used as map keys, along with the methods that return them. The number of
+// bytes written is returned along with any error that occurs.
+func (t *Table) KeyTypes(w io.Writer) (n int64, err error) {
+	for _, cols := range t.keys() {
+		nn, err := t.keyType(w, cols)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// keyType generates the key type for the table's cols and the method that
+// returns it.
+func (t *Table) keyType(w io.Writer, cols []string) (n int64, err error) {
+	name := t.keyTypeName(cols)
+	method := t.keyMethodName(cols)
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
+	if err != nil {
+		return 0, err
+	}
+
+	cmt, err := dbsql2go.StringToComments(fmt.Sprintf(keyTypeComment, name, t.name, strings.Join(cols, ", ")), 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(cmt)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("type %s struct {\n", name))
+	if err != nil {
+		return 0, err
+	}
+
+	var body []string
+	for _, v := range cols {
+		col := t.column(v)
+		if col == nil {
+			return 0, fmt.Errorf("%s: column %s not found", t.name, v)
+		}
+		expr, typ, valid := col.value(string(t.r), &t.cfg)
+		_, err = t.buf.WriteString(fmt.Sprintf("\t%s %s\n", col.fieldName, typ))
+		if err != nil {
+			return 0, err
+		}
+		if valid == "" {
+			body = append(body, fmt.Sprintf("\tk.%s = %s\n", col.fieldName, expr))
+			continue
+		}
+		body = append(body, fmt.Sprintf("\tif %s {\n\t\tk.%s = %s\n\t}\n", valid, col.fieldName, expr))
+	}
+
+	_, err = t.buf.WriteString("}\n\n")
+	if err != nil {
+		return 0, err
+	}
+
+	cmt, err = dbsql2go.StringToComments(fmt.Sprintf(keyMethodComment, method, name), 80)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(cmt)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("func (%c *%s) %s() %s {\n\tvar k %s\n%s\treturn k\n}\n", t.r, t.structName, method, name, name, strings.Join(body, "")))
+	if err != nil {
+		return 0, err
 	}
 
-	num := int2word.Capitalized(int64(len(t.sqlInf.WhereColumns)))
-	c, err := dbsql2go.StringToComments(fmt.Sprintf(selectPKInRangeComment, t.structName, title, t.name, t.structName, lower, num, t.buf.String()), 80)
+	return t.buf.WriteTo(w)
+}
+
+// PageFuncs generates the keyset pagination funcs for the table: PageAfter,
+// which SELECTs the rows after a primary key, and PageBefore, which SELECTs
+// the rows before a primary key. The primary key is compared using a row
+// constructor, e.g. (a, b) > (?, ?), so that composite keys are ordered by
+// all of their columns. Tables without a primary key have nothing written.
+// The number of bytes written is returned along with any error that occurs.
+func (t *Table) PageFuncs(w io.Writer) (n int64, err error) {
+	if t.key() == nil {
+		return 0, nil
+	}
+	n, err = t.pageFunc(w, true)
 	if err != nil {
-		return 0, err
+		return n, err
 	}
+	nn, err := t.pageFunc(w, false)
+	return n + nn, err
+}
 
-	t.buf.Reset()
+// pageFunc generates PageAfter, if after is true, or PageBefore.
+func (t *Table) pageFunc(w io.Writer, after bool) (n int64, err error) {
+	pk := t.key()
+	key := t.keyTypeName(pk.Columns)
+	name, cursor, comment, op, dir, last := t.structName+"PageAfter", "after", pageAfterComment, ">", "", "results[len(results)-1]"
+	if !after {
+		name, cursor, comment, op, dir, last = t.structName+"PageBefore", "before", pageBeforeComment, "<", " DESC", "results[0]"
+	}
 
-	err = t.buf.WriteByte('\n')
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(c)
+	cmt, err := dbsql2go.StringToComments(fmt.Sprintf(comment, name, t.name)+t.notDeletedComment(""), 80)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(fmt.Sprintf("func %sSelectInRange%s(db *sql.DB, args ...interface{}) (results []%s, err error) {\n\trows, err := db.Query(\"", t.structName, title, t.structName))
+	_, err = t.buf.WriteString(cmt)
 	if err != nil {
 		return 0, err
 	}
 
-	// write the sql
-	err = dbsql2go.SelectAndOrSQL.Execute(&t.buf, t.sqlInf)
+	cols := strings.Join(pk.Columns, ", ")
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(pk.Columns)), ", ")
+	args := make([]string, len(pk.Fields))
+	order := make([]string, len(pk.Columns))
+	for i, v := range pk.Columns {
+		args[i] = cursor + "." + pk.Fields[i]
+		order[i] = v + dir
+	}
+	if len(pk.Columns) > 1 {
+		cols = "(" + cols + ")"
+		placeholders = "(" + placeholders + ")"
+	}
+
+	_, err = t.buf.WriteString(fmt.Sprintf("func %s(ctx context.Context, db ContextQuerier, %s *%s, limit int) (results []%s, next *%s, err error) {\n\tquery := \"", name, cursor, key, t.structName, key))
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString("\", args...)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tdefer rows.Close()\n\n\tfor rows.Next() {\n\t\tvar ")
+	t.sqlInf.Columns = t.ColumnNames()
+	t.sqlInf.WhereColumns = nil
+	t.sqlInf.WhereNull = t.whereNotDeleted(false)
+	err = dbsql2go.SelectSQL.Execute(&t.buf, t.sqlInf)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(fmt.Sprintf("%c %s\n\t\terr = rows.Scan(", t.r, t.structName))
+	// the cursor's comparison is added to the WHERE, if there is one.
+	where := "WHERE"
+	if len(t.sqlInf.WhereNull) > 0 {
+		where = "AND"
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\"\n\targs := make([]interface{}, 0, %d)\n\tif %s != nil {\n\t\tquery += \" %s %s %s %s\"\n\t\targs = append(args, %s)\n\t}\n\trows, err := db.QueryContext(ctx, query+\" ORDER BY %s LIMIT ?\", append(args, limit)...)\n\tif err != nil {\n\t\treturn nil, nil, err\n\t}\n\tdefer rows.Close()\n\n", len(pk.Columns)+1, cursor, where, cols, op, placeholders, strings.Join(args, ", "), strings.Join(order, ", ")))
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(fmt.Sprintf("&%c.%s", t.r, t.columns[0].fieldName))
+	_, err = t.buf.WriteString(fmt.Sprintf("\tfor rows.Next() {\n\t\tvar %c %s\n\t\terr = rows.Scan(%s)\n\t\tif err != nil {\n\t\t\treturn nil, nil, err\n\t\t}\n\t\tresults = append(results, %c)\n\t}\n\terr = rows.Err()\n\tif err != nil {\n\t\treturn nil, nil, err\n\t}\n", t.r, t.structName, t.fieldArgs(string(t.r), t.ColumnNames(), true), t.r))
 	if err != nil {
 		return 0, err
 	}
 
-	for i := 1; i < len(t.columns); i++ {
-		_, err = t.buf.Write([]byte(fmt.Sprintf(", &%c.%s", t.r, t.columns[i].fieldName)))
+	if !after { // the rows were SELECTed in descending order.
+		_, err = t.buf.WriteString("\tfor i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {\n\t\tresults[i], results[j] = results[j], results[i]\n\t}\n")
 		if err != nil {
 			return 0, err
 		}
 	}
 
-	_, err = t.buf.WriteString(")\n\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\tresults = append(results, ")
-	if err != nil {
-		return 0, err
-	}
-
-	_, err = t.buf.WriteString(fmt.Sprintf("%c)\n\t}\n\n\treturn results, nil\n}\n", t.r))
+	_, err = t.buf.WriteString(fmt.Sprintf("\tif limit > 0 && len(results) == limit {\n\t\tk := %s.%s()\n\t\tnext = &k\n\t}\n\treturn results, next, nil\n}\n", last, t.keyMethodName(pk.Columns)))
 	if err != nil {
 		return 0, err
 	}
@@ -848,63 +3919,102 @@ func (t *Table) selectInRange(w io.Writer, title, lower string) (n int64, err er
 	return t.buf.WriteTo(w)
 }
 
-// DeletePKMethod generates the method for deleting a table row using its PK
-// and writes it to the writer. The number of bytes written is returned. If an
-// error occurs that is returned along with the number of bytes written. If the
-// table does not have a primary key, nothing will be written and the error will
-// be nil as this is not an error.
-func (t *Table) DeletePKMethod(w io.Writer) (n int64, err error) {
-	if t.pk < 0 {
-		return 0, nil // nothing to do
+// LoaderFuncs generates the funcs that load the rows related, by a foreign
+// key, to a slice of the table's rows using one query per batch of rows
+// instead of one query per row. For each of the table's foreign keys, the
+// referenced rows are loaded along with a method that returns the key of the
+// row referenced by a row; for each foreign key that references the table,
+// the referencing rows are loaded. If the table isn't part of a DB, nothing
+// is written. The number of bytes written is returned along with any error
+// that occurs.
+func (t *Table) LoaderFuncs(w io.Writer) (n int64, err error) {
+	if t.db == nil {
+		return 0, nil
 	}
-	// Reset the buffer so this method can use it.
-	t.buf.Reset()
-	err = t.buf.WriteByte(dbsql2go.LF)
+	names, err := t.AccessorNames()
 	if err != nil {
 		return 0, err
 	}
-
-	// write the comment
-	c, err := dbsql2go.StringToComments(fmt.Sprintf(deletePKComment, t.name), 80)
-	if err != nil {
-		return 0, err
+	for _, fk := range t.db.foreignKeys() {
+		if fk.Table != t.name {
+			continue
+		}
+		nn, err := t.fkKeyMethod(w, names[fk.Table+"."+fk.Name+".parent"], fk)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+		nn, err = t.loaderFunc(w, names[fk.Table+"."+fk.Name+".parent"], fk, false)
+		n += nn
+		if err != nil {
+			return n, err
+		}
 	}
+	for _, fk := range t.db.foreignKeys() {
+		if fk.RefTable != t.name {
+			continue
+		}
+		nn, err := t.loaderFunc(w, names[fk.Table+"."+fk.Name+".children"], fk, true)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
 
-	_, err = t.buf.WriteString(c)
+// fkKeyMethod generates the method that returns the key of the row that the
+// table's foreign key, fk, references. The method is the name of the parent
+// accessor followed by Key, e.g. CustomerKey.
+func (t *Table) fkKeyMethod(w io.Writer, accessor string, fk dbsql2go.Constraint) (n int64, err error) {
+	parent := t.db.table(fk.RefTable)
+	if parent == nil {
+		return 0, fmt.Errorf("%s: foreign key %s: table %s not found", t.name, fk.Name, fk.RefTable)
+	}
+	name := parent.keyTypeName(fk.RefColumns)
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(fmt.Sprintf("func(%c *%s) Delete(db *sql.DB) (n int64, err error) {\n\tres, err := db.Exec(\"", t.r, t.structName))
+	cmt, err := dbsql2go.StringToComments(fmt.Sprintf(fkKeyMethodComment, accessor+"Key", name, fk.RefTable, t.r, fk.Name), 80)
 	if err != nil {
 		return 0, err
 	}
 
-	err = t.deleteSQLPK()
+	_, err = t.buf.WriteString(cmt)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString("\", ")
+	_, err = t.buf.WriteString(fmt.Sprintf("func (%c *%s) %sKey() %s {\n\tvar k %s\n", t.r, t.structName, accessor, name, name))
 	if err != nil {
 		return 0, err
 	}
 
-	for i, v := range t.constraints[t.pk].Fields {
-		if i == 0 {
-			_, err = t.buf.WriteString(fmt.Sprintf("%c.%s", t.r, v))
-			if err != nil {
-				return 0, err
-			}
-			continue
+	for i, v := range fk.Columns {
+		col := t.column(v)
+		ref := parent.column(fk.RefColumns[i])
+		if col == nil || ref == nil {
+			return 0, fmt.Errorf("%s: foreign key %s: column %s or %s.%s not found", t.name, fk.Name, v, fk.RefTable, fk.RefColumns[i])
+		}
+		expr, typ, valid := col.value(string(t.r), &t.cfg)
+		_, refTyp, _ := ref.value("", &parent.cfg)
+		if typ != refTyp {
+			expr = fmt.Sprintf("%s(%s)", refTyp, expr)
+		}
+		if valid == "" {
+			_, err = t.buf.WriteString(fmt.Sprintf("\tk.%s = %s\n", ref.fieldName, expr))
+		} else {
+			_, err = t.buf.WriteString(fmt.Sprintf("\tif %s {\n\t\tk.%s = %s\n\t}\n", valid, ref.fieldName, expr))
 		}
-		_, err = t.buf.WriteString(fmt.Sprintf(", %c.%s", t.r, v))
 		if err != nil {
 			return 0, err
 		}
 	}
 
-	_, err = t.buf.WriteString(")\n\tif err != nil {\n\t\treturn 0, err\n\t}\n\treturn res.RowsAffected()\n}\n")
+	_, err = t.buf.WriteString("\treturn k\n}\n")
 	if err != nil {
 		return 0, err
 	}
@@ -912,210 +4022,200 @@ func (t *Table) DeletePKMethod(w io.Writer) (n int64, err error) {
 	return t.buf.WriteTo(w)
 }
 
-// deleteSQLPK returns a DELETE statement for the table that deletes a row
-// using the tables PK. If the table does not have a PK, no SQL will be
-// generated and a nil will be returned as this is not an error state.
-func (t *Table) deleteSQLPK() error {
-	t.sqlInf.WhereColumns = t.constraints[t.pk].Columns
-	err := dbsql2go.DeleteSQL.Execute(&t.buf, t.sqlInf)
-	if err != nil {
-		return err
+// loaderFunc generates the func that loads the rows related to a slice of the
+// table's rows by the foreign key, fk. If children is true, the table is the
+// referenced table and the referencing rows are loaded; otherwise the table
+// is the referencing table and the referenced rows are loaded. accessor is
+// the name of the corresponding accessor, which is used in the func's name,
+// e.g. LoadCustomerOrders.
+func (t *Table) loaderFunc(w io.Writer, accessor string, fk dbsql2go.Constraint, children bool) (n int64, err error) {
+	parent := t.db.table(fk.RefTable)
+	child := t.db.table(fk.Table)
+	if parent == nil || child == nil {
+		return 0, fmt.Errorf("%s: foreign key %s: table %s or %s not found", t.name, fk.Name, fk.Table, fk.RefTable)
 	}
-	return nil
-}
-
-// InsertMethod generates the method for inserting the Table's data into the
-// db table as a row. The number of bytes written to the writer is returned
-// along with any error that may occur, if any. If the table is a view, no
-// insert method will be generated.
-func (t *Table) InsertMethod(w io.Writer) (n int64, err error) {
-	if t.IsView() {
-		return 0, nil
+	name := "Load" + t.structName + accessor
+	key := parent.keyTypeName(fk.RefColumns)
+	param := mixedcase.Unexported(plural(t.structName))
+	// the loaded table, the columns used to find its rows, the method that
+	// returns the key of t's rows and the expression for the key of a loaded
+	// row.
+	dst, where, srcKey, dstKey, result := child, fk.Columns, parent.keyMethodName(fk.RefColumns), "row."+accessor+"Key()", "[]"+child.structName
+	nulls, err := t.nullConds(param+"[i]", fk.RefColumns)
+	if !children {
+		dst, where, srcKey, dstKey, result = parent, fk.RefColumns, accessor+"Key", "row."+parent.keyMethodName(fk.RefColumns)+"()", "*"+parent.structName
+		nulls, err = t.nullConds(param+"[i]", fk.Columns)
 	}
-
-	t.buf.Reset()
-
-	err = t.buf.WriteByte(dbsql2go.LF)
 	if err != nil {
 		return 0, err
 	}
-
-	// write the comment
-	c, err := dbsql2go.StringToComments(fmt.Sprintf(insertPKComment, t.name), 80)
-	if err != nil {
-		return 0, err
+	if children {
+		// the key of the loaded rows uses the name of the parent accessor.
+		names, err := child.AccessorNames()
+		if err != nil {
+			return 0, err
+		}
+		dstKey = "row." + names[fk.Table+"."+fk.Name+".parent"] + "Key()"
 	}
 
-	_, err = t.buf.WriteString(c)
+	t.buf.Reset()
+	err = t.buf.WriteByte(dbsql2go.LF)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(fmt.Sprintf("func(%c *%s) Insert(db *sql.DB) (id int64, err error) {\n\tres, err := db.Exec(\"", t.r, t.structName))
+	size := MaxPlaceholders / len(where)
+	var s string
+	if children {
+		s = fmt.Sprintf(loadChildrenComment, name, fk.Table, param, fk.Name, key, size, param)
+	} else {
+		s = fmt.Sprintf(loadParentsComment, name, fk.RefTable, param, fk.Name, key, size, param)
+	}
+	cmt, err := dbsql2go.StringToComments(s+dst.notDeletedComment(""), 80)
 	if err != nil {
 		return 0, err
 	}
 
-	err = t.insertSQL()
+	_, err = t.buf.WriteString(cmt)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString("\", ")
+	_, err = t.buf.WriteString(fmt.Sprintf("func %s(ctx context.Context, db ContextQuerier, %s []%s) (map[%s]%s, error) {\n\tconst size = %d // the maximum number of keys per query\n\tvar keys []%s\n\tseen := make(map[%s]bool, len(%s))\n\tfor i := range %s {\n", name, param, t.structName, key, result, size, key, key, param, param))
 	if err != nil {
 		return 0, err
 	}
 
-	// buld the struct field stuff: skip the pk Columns and only use the fields
-	// that have corresponding columns in sqlInf
-	var j int // index into the sqlInf Columns
-	for _, v := range t.columns {
-		// skip if this column isn't in sqlInf; columns are in same order
-		if v.Name != t.sqlInf.Columns[j] {
-			continue
-		}
-		j++         // point to next column
-		if j == 1 { // if this is the first element added, don't prefix with ', '
-			_, err = t.buf.WriteString(fmt.Sprintf("&%c.%s", t.r, v.fieldName))
-			if err != nil {
-				return 0, err
-			}
-			continue
-		}
-		_, err = t.buf.WriteString(fmt.Sprintf(", &%c.%s", t.r, v.fieldName))
+	if len(nulls) > 0 {
+		_, err = t.buf.WriteString(fmt.Sprintf("\t\tif %s {\n\t\t\tcontinue\n\t\t}\n", strings.Join(nulls, " || ")))
 		if err != nil {
 			return 0, err
 		}
 	}
 
-	_, err = t.buf.WriteString(")\n\tif err != nil {\n\t\treturn 0, err\n\t}\n\treturn res.LastInsertID()\n}\n")
+	_, err = t.buf.WriteString(fmt.Sprintf("\t\tk := %s[i].%s()\n\t\tif !seen[k] {\n\t\t\tseen[k] = true\n\t\t\tkeys = append(keys, k)\n\t\t}\n\t}\n\tresults := make(map[%s]%s, len(keys))\n", param, srcKey, key, result))
 	if err != nil {
 		return 0, err
 	}
 
-	return t.buf.WriteTo(w)
-}
-
-// insertSQL returns an INSERT statement for the table.
-func (t *Table) insertSQL() error {
-	// set up the relevant infor for the SQL generation; Table is already set.
-	t.sqlInf.Columns = t.NonAutoIncrementColumnNames()
-
-	err := dbsql2go.InsertSQL.Execute(&t.buf, t.sqlInf)
-	if err != nil {
-		return err
+	args := make([]string, len(fk.RefColumns))
+	for i, v := range fk.RefColumns {
+		args[i] = "k." + parent.column(v).fieldName
 	}
-	return nil
-}
-
-// UpdateMethod generates the method for updatating a table row using its PK
-// and writes it to the writer. The number of bytes written is returned. If an
-// error occurs that is returned along with the number of bytes written. If the
-// table does not have a primary key, nothing will be written and the error will
-// be nil as this is not an error.
-func (t *Table) UpdateMethod(w io.Writer) (n int64, err error) {
-	if t.pk < 0 {
-		// nothing to do
-		return 0, nil
+	placeholder := strings.TrimSuffix(strings.Repeat("?, ", len(where)), ", ")
+	in := strings.Join(where, ", ")
+	if len(where) > 1 {
+		placeholder = "(" + placeholder + ")"
+		in = "(" + in + ")"
 	}
-
-	err = t.buf.WriteByte(dbsql2go.LF)
+	capacity := "len(batch)"
+	if len(where) > 1 {
+		capacity = fmt.Sprintf("len(batch)*%d", len(where))
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf("\tfor len(keys) > 0 {\n\t\tbatch := keys\n\t\tif len(batch) > size {\n\t\t\tbatch = batch[:size]\n\t\t}\n\t\tkeys = keys[len(batch):]\n\t\targs := make([]interface{}, 0, %s)\n\t\tfor _, k := range batch {\n\t\t\targs = append(args, %s)\n\t\t}\n\t\tquery := \"", capacity, strings.Join(args, ", ")))
 	if err != nil {
 		return 0, err
 	}
 
-	// write the comment
-	c, err := dbsql2go.StringToComments(fmt.Sprintf(updatePKComment, t.name), 80)
+	inf := dst.sqlInf
+	inf.Columns = dst.ColumnNames()
+	inf.WhereColumns = nil
+	inf.WhereNull = dst.whereNotDeleted(false)
+	err = dbsql2go.SelectSQL.Execute(&t.buf, inf)
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(c)
+	conj := "WHERE"
+	if len(inf.WhereNull) > 0 {
+		conj = "AND"
+	}
+	_, err = t.buf.WriteString(fmt.Sprintf(" %s %s IN (\" + strings.Repeat(\"%s, \", len(batch)-1) + \"%s)\"\n\t\trows, err := db.QueryContext(ctx, query, args...)\n\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\tfor rows.Next() {\n\t\t\tvar row %s\n\t\t\terr = rows.Scan(%s)\n\t\t\tif err != nil {\n\t\t\t\trows.Close()\n\t\t\t\treturn nil, err\n\t\t\t}\n", conj, in, placeholder, placeholder, dst.structName, dst.fieldArgs("row", dst.ColumnNames(), true)))
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString(fmt.Sprintf("func(%c *%s) Update(db *sql.DB) (n int64, err error) {\n\tres, err := db.Exec(\"", t.r, t.structName))
+	if children {
+		_, err = t.buf.WriteString(fmt.Sprintf("\t\t\tk := %s\n\t\t\tresults[k] = append(results[k], row)\n", dstKey))
+	} else {
+		_, err = t.buf.WriteString(fmt.Sprintf("\t\t\tresults[%s] = &row\n", dstKey))
+	}
 	if err != nil {
 		return 0, err
 	}
 
-	err = t.updateSQL()
+	_, err = t.buf.WriteString("\t\t}\n\t\terr = rows.Err()\n\t\trows.Close()\n\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t}\n\treturn results, nil\n}\n")
 	if err != nil {
 		return 0, err
 	}
 
-	_, err = t.buf.WriteString("\", ")
-	if err != nil {
-		return 0, err
+	return t.buf.WriteTo(w)
+}
+
+// plural returns the plural of a struct name.
+func plural(s string) string {
+	switch {
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "z"),
+		strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiouAEIOU", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
 	}
+	return s + "s"
+}
 
-	/*
-		_, err = w.Write([]byte(""))
-		if err != nil {
-			return err
-		}
-	*/
-	var j int //keep track of what's been added
+// HasUniqueKey returns whether or not the table has a primary key or a
+// unique constraint.
+func (t *Table) HasUniqueKey() bool {
+	return t.uniqueKey() != nil
+}
 
-	// buld the struct field stuff
-	for _, v := range t.columns {
-		var b bool
-		// if the column isn't in the list of columns added to the query, skip it
-		for _, col := range t.sqlInf.Columns {
-			if v.Name == col {
-				b = true
-				break
-			}
+// uniqueKey returns the table's primary key or, if it doesn't have one, its
+// first unique constraint. If the table has neither, nil is returned.
+func (t *Table) uniqueKey() *dbsql2go.Constraint {
+	if pk := t.key(); pk != nil {
+		return pk
+	}
+	for i := range t.constraints {
+		if t.constraints[i].Type == dbsql2go.Unique {
+			return &t.constraints[i]
 		}
-		if !b {
+	}
+	return nil
+}
+
+// surrogateKey returns the index, in constraints, of the key that identifies
+// the table's rows when it doesn't have a primary key: the first unique
+// constraint whose columns are all NOT NULL. If there isn't one, -1 is
+// returned.
+func (t *Table) surrogateKey() int {
+	for i, c := range t.constraints {
+		if c.Type != dbsql2go.Unique {
 			continue
 		}
-		j++
-		if j == 1 {
-			_, err = t.buf.WriteString(fmt.Sprintf("&%c.%s", t.r, v.fieldName))
-			if err != nil {
-				return 0, err
+		notNull := true
+		for _, name := range c.Columns {
+			if col := t.column(name); col == nil || col.IsNullable != "NO" {
+				notNull = false
+				break
 			}
-			continue
-		}
-		_, err = t.buf.WriteString(fmt.Sprintf(", &%c.%s", t.r, v.fieldName))
-		if err != nil {
-			return 0, err
 		}
-	}
-
-	for _, v := range t.constraints[t.pk].Fields {
-		_, err = t.buf.WriteString(fmt.Sprintf(", &%c.%s", t.r, v))
-		if err != nil {
-			return 0, err
+		if notNull {
+			return i
 		}
 	}
-
-	_, err = t.buf.WriteString(")\n\tif err != nil {\n\t\treturn 0, err\n\t}\n\treturn res.RowsAffected()\n}")
-	if err != nil {
-		return 0, err
-	}
-
-	err = t.buf.WriteByte(dbsql2go.LF)
-	if err != nil {
-		return 0, err
-	}
-
-	return t.buf.WriteTo(w)
+	return -1
 }
 
-// updateSQLPK returns an UPDATE statement for the table that updates all
-// non-auto increment columns using the table's pk in the WHERE clause. If
-// the table does not have a PK, no UPDATE statement will be generated and a
-// nil will be returned as this is not an error state.
-func (t *Table) updateSQL() error {
-	// set up the relevant infor for the SQL generation; Table is already set.
-	t.sqlInf.Columns = t.NonAutoIncrementColumnNames()
-	t.sqlInf.WhereColumns = t.constraints[t.pk].Columns
-	err := dbsql2go.UpdateSQL.Execute(&t.buf, t.sqlInf)
-	if err != nil {
-		return err
+// key returns the constraint that identifies the table's rows: its primary
+// key or, if it doesn't have one, its surrogate key. If the table has neither,
+// nil is returned. The generated code uses it wherever a primary key is used.
+func (t *Table) key() *dbsql2go.Constraint {
+	if pk := t.PK(); pk != nil {
+		return pk
+	}
+	if i := t.surrogateKey(); i >= 0 {
+		return &t.constraints[i]
 	}
 	return nil
 }
@@ -1149,58 +4249,237 @@ type Column struct {
 	Privileges       string
 	Comment          string
 	fieldName        string
+	table            string // the name of the column's table
 }
 
-func (c *Column) Go() []byte {
+// Go returns the column's struct field definition. How nullable columns are
+// represented depends on the NullStrategy in cfg. The field has the struct
+// tags specified by the Tags in cfg, if any.
+func (c *Column) Go(cfg *dbsql2go.Config) []byte {
 	n := make([]byte, 0, len(c.Name)+16) // add enough cap to handle most datatypes w/o growing
 	n = append(n, []byte(c.fieldName)...)
 	n = append(n, ' ')
+	n = append(n, []byte(c.goType(cfg))...)
+	if tag := cfg.StructTag(c.table, c.Name, c.IsNullable == "YES"); tag != "" {
+		n = append(n, ' ')
+		n = append(n, []byte(tag)...)
+	}
+	return n
+}
+
+// goType returns the Go type of the column. Nullable columns use the type
+// specified by the NullStrategy. Types that resolve to []byte are always
+// []byte as a nil slice represents NULL. A type override in cfg is used as
+// is; it is up to that type to handle NULL. With NullSQL, time columns are
+// mysql.NullTime even if they are NOT NULL, so that they can be scanned
+// without the driver's parseTime option; the other strategies use time.Time,
+// which requires it.
+func (c *Column) goType(cfg *dbsql2go.Config) string {
+	if typ, ok := cfg.ColumnType(c.table, c.Name); ok {
+		return typ
+	}
+	if u := c.uuidType(cfg); u != "" {
+		if c.IsNullable != "YES" {
+			return u
+		}
+		if cfg.NullStrategy == dbsql2go.NullGeneric {
+			return "sql.Null[" + u + "]"
+		}
+		return "*" + u // there isn't a sql.Null type for UUIDs.
+	}
+	base := c.baseType(cfg)
+	if base == "[]byte" || base == c.DataType { // nil already means NULL or the type isn't supported
+		return base
+	}
+	if c.IsNullable != "YES" {
+		if base == "time.Time" && cfg.NullStrategy == dbsql2go.NullSQL {
+			return "mysql.NullTime"
+		}
+		return base
+	}
+	switch cfg.NullStrategy {
+	case dbsql2go.NullPointer:
+		return "*" + base
+	case dbsql2go.NullGeneric:
+		return "sql.Null[" + base + "]"
+	}
+	switch base {
+	case "int8", "int16", "int32", "int64":
+		return "sql.NullInt64"
+	case "float32", "float64":
+		return "sql.NullFloat64"
+	case "time.Time":
+		return "mysql.NullTime"
+	default:
+		return "sql.NullString"
+	}
+}
+
+// value returns the expression for the value of the column's field in v, a
+// variable of its table's struct type, without any NULL wrapper, e.g.
+// v.Code.String, along with the expression's type and the condition that is
+// true when the field is not NULL. []byte values are converted to string so
+// that they are comparable. If the column can't be NULL, or its type is
+// overridden, the condition is empty.
+func (c *Column) value(v string, cfg *dbsql2go.Config) (expr, typ, valid string) {
+	f := v + "." + c.fieldName
+	typ = c.goType(cfg)
+	if _, ok := cfg.ColumnType(c.table, c.Name); ok {
+		return f, typ, ""
+	}
 	if c.IsNullable == "YES" {
-		switch c.DataType {
-		case "int", "tinyint", "smallint", "mediumint", "bigint":
-			return append(n, []byte("sql.NullInt64")...)
-		case "decimal":
-			return append(n, []byte("sql.NullFloat64")...)
-		case "timestamp", "date", "datetime":
-			return append(n, []byte("mysql.NullTime")...)
-		case "tinyblob", "blob", "mediumblob", "longblob",
-			"tinytext", "text", "mediumtext", "longtext",
-			"binary", "varbinary":
-			return append(n, []byte("[]byte")...)
-		case "char", "varchar", "time", "year", "enum", "set":
-			return append(n, []byte("sql.NullString")...)
-		default:
-			return append(n, []byte(c.DataType)...)
+		valid = f + ".Valid"
+	}
+	switch {
+	case typ == "[]byte":
+		if valid != "" {
+			valid = f + " != nil"
+		}
+		return "string(" + f + ")", "string", valid
+	case strings.HasPrefix(typ, "*"):
+		return "*" + f, typ[1:], f + " != nil"
+	case strings.HasPrefix(typ, "sql.Null["):
+		return f + ".V", typ[len("sql.Null[") : len(typ)-1], valid
+	case typ == "sql.NullInt64":
+		return f + ".Int64", "int64", valid
+	case typ == "sql.NullFloat64":
+		return f + ".Float64", "float64", valid
+	case typ == "sql.NullString":
+		return f + ".String", "string", valid
+	case typ == "mysql.NullTime":
+		return f + ".Time", "time.Time", valid
+	}
+	return f, typ, ""
+}
+
+// jsonType returns the type of the column's field in its table's JSON type and
+// whether or not the field's type is a NULL wrapper type, e.g. sql.NullString,
+// that the JSON type replaces with the type that it wraps, or, if the column
+// is nullable, with a pointer to it. Other types are used as is.
+func (c *Column) jsonType(cfg *dbsql2go.Config) (typ string, wrapped bool) {
+	typ = c.goType(cfg)
+	if _, ok := cfg.ColumnType(c.table, c.Name); ok {
+		return typ, false
+	}
+	if !strings.HasPrefix(typ, "sql.Null") && typ != "mysql.NullTime" {
+		return typ, false
+	}
+	_, typ, valid := c.value("", cfg)
+	if valid != "" {
+		return "*" + typ, true
+	}
+	return typ, true
+}
+
+// nullValue returns the value of the column's field, a NULL wrapper type, for
+// the expression expr of the type that it wraps; the value isn't NULL. If the
+// field isn't a NULL wrapper type, expr is returned.
+func (c *Column) nullValue(expr string, cfg *dbsql2go.Config) string {
+	typ := c.goType(cfg)
+	switch {
+	case strings.HasPrefix(typ, "sql.Null["):
+		return typ + "{V: " + expr + ", Valid: true}"
+	case typ == "sql.NullInt64":
+		return typ + "{Int64: " + expr + ", Valid: true}"
+	case typ == "sql.NullFloat64":
+		return typ + "{Float64: " + expr + ", Valid: true}"
+	case typ == "sql.NullString":
+		return typ + "{String: " + expr + ", Valid: true}"
+	case typ == "mysql.NullTime":
+		return typ + "{Time: " + expr + ", Valid: true}"
+	}
+	return expr
+}
+
+// timeField returns the value of the column's field, a time column, for the
+// time.Time expression expr, which must be addressable if the field is a
+// pointer. If expr is empty, the value is NULL.
+func (c *Column) timeField(expr string, cfg *dbsql2go.Config) string {
+	typ := c.goType(cfg)
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		if expr == "" {
+			return "nil"
 		}
+		return "&" + expr
+	case expr == "":
+		return typ + "{}"
 	}
+	return c.nullValue(expr, cfg)
+}
+
+// baseType returns the Go type that most closely matches the column's type
+// when NULL is not a concern. If there isn't a corresponding Go type, the
+// column's data type is returned. Text columns are strings unless they hold
+// binary data or cfg.TextAsBytes is set.
+func (c *Column) baseType(cfg *dbsql2go.Config) string {
 	switch c.DataType {
 	case "int":
-		return append(n, []byte("int32")...)
+		return "int32"
 	case "tinyint":
-		return append(n, []byte("int8")...)
+		return "int8"
 	case "smallint":
-		return append(n, []byte("int16")...)
+		return "int16"
 	case "mediumint":
-		return append(n, []byte("int32")...)
+		return "int32"
 	case "bigint":
-		return append(n, []byte("int64")...)
+		return "int64"
 	case "char", "varchar":
-		return append(n, []byte("string")...)
+		return "string"
 	case "decimal":
-		return append(n, []byte("float64")...)
+		return "float64"
 	case "timestamp", "date", "datetime":
-		return append(n, []byte("mysql.NullTime")...)
+		return "time.Time"
+	case "tinytext", "text", "mediumtext", "longtext":
+		if c.IsBinary() || cfg.TextAsBytes {
+			return "[]byte"
+		}
+		return "string"
 	case "tinyblob", "blob", "mediumblob", "longblob",
-		"tinytext", "text", "mediumtext", "longtext",
 		"binary", "varbinary":
-		return append(n, []byte("[]byte")...)
+		return "[]byte"
 	case "time", "year", "enum", "set":
-		return append(n, []byte("string")...)
+		return "string"
 	default:
-		return append(n, []byte(c.DataType)...)
+		return c.DataType
 	}
 }
 
+// uuidType returns the uuid package type to use for the column if it holds
+// UUIDs; otherwise an empty string is returned. Only BINARY(16) columns and
+// CHAR(36) or VARCHAR(36) columns can hold UUIDs.
+func (c *Column) uuidType(cfg *dbsql2go.Config) string {
+	if !cfg.IsUUIDColumn(c.table, c.Name, c.Comment) {
+		return ""
+	}
+	switch c.DataType {
+	case "binary", "varbinary":
+		if c.CharMaxLen.Int64 != 16 {
+			return ""
+		}
+		if cfg.UUIDSwap {
+			return "uuid.Swapped"
+		}
+		return "uuid.Binary"
+	case "char", "varchar":
+		if c.CharMaxLen.Int64 != 36 {
+			return ""
+		}
+		return "uuid.Text"
+	}
+	return ""
+}
+
+// IsBinary returns whether or not the column holds binary data. Columns
+// without a character set, e.g. BLOBs, and columns using the binary character
+// set or collation hold binary data.
+func (c *Column) IsBinary() bool {
+	if !c.CharacterSet.Valid || c.CharacterSet.String == "binary" {
+		return true
+	}
+	return c.Collation.Valid && c.Collation.String == "binary"
+}
+
 // SetFieldName sets the column's field name; the name of the field in the
 // table struct in which this column's value will be put.
 func (c *Column) SetFieldName() {
@@ -1241,11 +4520,6 @@ type Constraint struct {
 	RefCol   sql.NullString // Column on the refered to table of the constraint for Foreign Keys.
 }
 
-// ImportString returns the import string for importing the mysql db driver.
-func Import() string {
-	return `_ "github.com/go-sql-driver/mysql"`
-}
-
 type View struct {
 	Table               string
 	ViewDefinition      string
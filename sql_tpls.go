@@ -10,4 +10,21 @@ type TableSQL struct {
 	WhereColumns       []string // the where column names
 	WhereComparisonOps []string // the comparison operator for the corresponding column index
 	WhereConditions    []string // The conditional operator for Column pairs.
+	UpdateColumns      []string // the columns that are UPDATEd on a duplicate key
+	IncrementColumns   []string // the columns that an UPDATE increments, e.g. a version
+	WhereNull          []string // the columns that must be NULL for a row to be SELECTed, e.g. a soft delete column
+	RowAlias           string   // the alias of the new row for ON DUPLICATE KEY UPDATE; if empty, VALUES() is used
+	// WhereTuple compares the WhereColumns as a row constructor, e.g.
+	// (a, b) > (?, ?): each of the WhereComparisonOps compares all of the
+	// WhereColumns and the WhereConditions join the comparisons.
+	WhereTuple bool
+	// WhereArgs are the names of the arguments used in the WHERE clause
+	// comments, one per comparison; if empty, arg[i] is used.
+	WhereArgs []string
+	// WhereNullSafe compares the WhereColumns of an UPDATE or a DELETE using
+	// the NULL-safe <=>, which is true when both sides are NULL, instead of =.
+	WhereNullSafe bool
+	// Limit is the maximum number of rows that an UPDATE or a DELETE
+	// affects; if 0, there isn't a limit.
+	Limit int
 }
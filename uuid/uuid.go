@@ -0,0 +1,254 @@
+// Copyright 2016-17 Joel Scoble.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// package uuid provides the UUID types used by code generated by dbsql2go for
+// UUID columns along with pluggable generators for new IDs.
+//
+// A UUID can be stored either as its 16 bytes, e.g. BINARY(16), or as its 36
+// character text representation, e.g. CHAR(36). Binary, Swapped, and Text all
+// read either format; they differ in the format that they write. Swapped is
+// for binary UUIDs that were stored using MySQL's UUID_TO_BIN(x, 1), which
+// moves the time-high and time-mid fields in front of time-low.
+package uuid
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// UUID is a 128 bit universally unique identifier.
+type UUID [16]byte
+
+// Nil is the zero UUID.
+var Nil UUID
+
+// Parse parses the text representation of a UUID: either the 36 character
+// form, xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, or its 32 hex digits without
+// the hyphens.
+func Parse(s string) (UUID, error) {
+	var u UUID
+	h := s
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, InvalidErr{s}
+		}
+		h = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return u, InvalidErr{s}
+	}
+	_, err := hex.Decode(u[:], []byte(h))
+	if err != nil {
+		return Nil, InvalidErr{s}
+	}
+	return u, nil
+}
+
+// String returns the 36 character text representation of the UUID.
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// IsZero returns whether or not the UUID is the Nil UUID.
+func (u UUID) IsZero() bool {
+	return u == Nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *UUID) UnmarshalText(b []byte) error {
+	v, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Binary is a UUID that is stored as its 16 bytes, e.g. BINARY(16).
+type Binary UUID
+
+// Scan implements sql.Scanner.
+func (b *Binary) Scan(src interface{}) error {
+	return scan((*UUID)(b), src, false)
+}
+
+// Value implements driver.Valuer.
+func (b Binary) Value() (driver.Value, error) {
+	return b[:], nil
+}
+
+func (b Binary) String() string                { return UUID(b).String() }
+func (b Binary) MarshalText() ([]byte, error)  { return UUID(b).MarshalText() }
+func (b *Binary) UnmarshalText(t []byte) error { return (*UUID)(b).UnmarshalText(t) }
+
+// Swapped is a UUID that is stored as 16 bytes with its time-low and
+// time-high fields swapped, as done by MySQL's UUID_TO_BIN(x, 1).
+type Swapped UUID
+
+// Scan implements sql.Scanner.
+func (s *Swapped) Scan(src interface{}) error {
+	return scan((*UUID)(s), src, true)
+}
+
+// Value implements driver.Valuer.
+func (s Swapped) Value() (driver.Value, error) {
+	return swap(UUID(s)), nil
+}
+
+func (s Swapped) String() string                { return UUID(s).String() }
+func (s Swapped) MarshalText() ([]byte, error)  { return UUID(s).MarshalText() }
+func (s *Swapped) UnmarshalText(t []byte) error { return (*UUID)(s).UnmarshalText(t) }
+
+// Text is a UUID that is stored as its 36 character text representation,
+// e.g. CHAR(36).
+type Text UUID
+
+// Scan implements sql.Scanner.
+func (t *Text) Scan(src interface{}) error {
+	return scan((*UUID)(t), src, false)
+}
+
+// Value implements driver.Valuer.
+func (t Text) Value() (driver.Value, error) {
+	return UUID(t).String(), nil
+}
+
+func (t Text) String() string                { return UUID(t).String() }
+func (t Text) MarshalText() ([]byte, error)  { return UUID(t).MarshalText() }
+func (t *Text) UnmarshalText(b []byte) error { return (*UUID)(t).UnmarshalText(b) }
+
+// scan sets u from src. A 16 byte src is the binary form, which is unswapped
+// if swapped is true; anything else is parsed as the text form.
+func scan(u *UUID, src interface{}, swapped bool) error {
+	switch v := src.(type) {
+	case nil:
+		*u = Nil
+		return nil
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			if swapped {
+				*u = unswap(*u)
+			}
+			return nil
+		}
+		return u.UnmarshalText(v)
+	case string:
+		return u.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("uuid: cannot scan %T into a UUID", src)
+	}
+}
+
+// swap returns the UUID_TO_BIN(u, 1) byte order of u.
+func swap(u UUID) []byte {
+	b := make([]byte, 16)
+	copy(b[0:2], u[6:8])
+	copy(b[2:4], u[4:6])
+	copy(b[4:8], u[0:4])
+	copy(b[8:], u[8:])
+	return b
+}
+
+// unswap reverses swap.
+func unswap(s UUID) UUID {
+	var u UUID
+	copy(u[0:4], s[4:8])
+	copy(u[4:6], s[2:4])
+	copy(u[6:8], s[0:2])
+	copy(u[8:], s[8:])
+	return u
+}
+
+// Generator returns a new UUID.
+type Generator func() (UUID, error)
+
+// Generate is used by the generated code to create the IDs of rows being
+// INSERTed whose UUID primary key is not set. It defaults to NewV4 and can be
+// replaced, e.g. with NewV7 or NewULID; this should be done before any
+// INSERTs are made.
+var Generate Generator = NewV4
+
+// NewV4 returns a random, version 4, UUID.
+func NewV4() (UUID, error) {
+	var u UUID
+	_, err := rand.Read(u[:])
+	if err != nil {
+		return Nil, err
+	}
+	u[6] = u[6]&0x0f | 0x40 // version 4
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+	return u, nil
+}
+
+// NewV7 returns a time-ordered, version 7, UUID: the first 48 bits are the
+// Unix time in milliseconds and the rest are random.
+func NewV7() (UUID, error) {
+	u, err := newTimestamped()
+	if err != nil {
+		return Nil, err
+	}
+	u[6] = u[6]&0x0f | 0x70 // version 7
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+	return u, nil
+}
+
+// NewULID returns a ULID as a UUID: the first 48 bits are the Unix time in
+// milliseconds and the remaining 80 bits are random. Unlike NewV7, no bits
+// are used for the version and variant.
+func NewULID() (UUID, error) {
+	return newTimestamped()
+}
+
+// newTimestamped returns a UUID whose first 48 bits are the current Unix time
+// in milliseconds; the rest of the bits are random.
+func newTimestamped() (UUID, error) {
+	var u UUID
+	_, err := rand.Read(u[6:])
+	if err != nil {
+		return Nil, err
+	}
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(time.Now().UnixNano()/int64(time.Millisecond)))
+	copy(u[:6], ts[2:])
+	return u, nil
+}
+
+// InvalidErr is returned when a string is not a valid UUID.
+type InvalidErr struct {
+	Value string
+}
+
+func (i InvalidErr) Error() string {
+	return i.Value + " is not a valid UUID"
+}
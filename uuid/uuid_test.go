@@ -0,0 +1,119 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      error
+	}{
+		{"6ccd780c-baba-1026-9564-5b8c656024db", "6ccd780c-baba-1026-9564-5b8c656024db", nil},
+		{"6CCD780C-BABA-1026-9564-5B8C656024DB", "6ccd780c-baba-1026-9564-5b8c656024db", nil},
+		{"6ccd780cbaba102695645b8c656024db", "6ccd780c-baba-1026-9564-5b8c656024db", nil},
+		{"", "", InvalidErr{""}},
+		{"6ccd780c_baba_1026_9564_5b8c656024db", "", InvalidErr{"6ccd780c_baba_1026_9564_5b8c656024db"}},
+		{"6ccd780c-baba-1026-9564-5b8c656024dz", "", InvalidErr{"6ccd780c-baba-1026-9564-5b8c656024dz"}},
+	}
+	for _, test := range tests {
+		u, err := Parse(test.value)
+		if err != test.err {
+			t.Errorf("%s: got %v want %v", test.value, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if u.String() != test.expected {
+			t.Errorf("%s: got %s want %s", test.value, u, test.expected)
+		}
+	}
+}
+
+func TestStorageFormats(t *testing.T) {
+	u, err := Parse("6ccd780c-baba-1026-9564-5b8c656024db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := []byte{0x6c, 0xcd, 0x78, 0x0c, 0xba, 0xba, 0x10, 0x26, 0x95, 0x64, 0x5b, 0x8c, 0x65, 0x60, 0x24, 0xdb}
+	// UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1)
+	swapped := []byte{0x10, 0x26, 0xba, 0xba, 0x6c, 0xcd, 0x78, 0x0c, 0x95, 0x64, 0x5b, 0x8c, 0x65, 0x60, 0x24, 0xdb}
+
+	v, _ := Binary(u).Value()
+	if !bytes.Equal(v.([]byte), raw) {
+		t.Errorf("Binary.Value: got %x want %x", v, raw)
+	}
+	v, _ = Swapped(u).Value()
+	if !bytes.Equal(v.([]byte), swapped) {
+		t.Errorf("Swapped.Value: got %x want %x", v, swapped)
+	}
+	v, _ = Text(u).Value()
+	if v.(string) != u.String() {
+		t.Errorf("Text.Value: got %s want %s", v, u)
+	}
+
+	// each type must read both the binary and the text forms.
+	srcs := []interface{}{u.String(), []byte(u.String())}
+	for _, src := range append(srcs, raw) {
+		var b Binary
+		err = b.Scan(src)
+		if err != nil || UUID(b) != u {
+			t.Errorf("Binary.Scan(%v): got %s, %v want %s", src, b, err, u)
+		}
+		var tx Text
+		err = tx.Scan(src)
+		if err != nil || UUID(tx) != u {
+			t.Errorf("Text.Scan(%v): got %s, %v want %s", src, tx, err, u)
+		}
+	}
+	for _, src := range append(srcs, swapped) {
+		var s Swapped
+		err = s.Scan(src)
+		if err != nil || UUID(s) != u {
+			t.Errorf("Swapped.Scan(%v): got %s, %v want %s", src, s, err, u)
+		}
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     Generator
+		version byte
+	}{
+		{"v4", NewV4, 4},
+		{"v7", NewV7, 7},
+	}
+	for _, test := range tests {
+		u, err := test.gen()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		if u.IsZero() {
+			t.Errorf("%s: got the Nil UUID", test.name)
+		}
+		if u[6]>>4 != test.version {
+			t.Errorf("%s: version: got %d want %d", test.name, u[6]>>4, test.version)
+		}
+		if u[8]&0xc0 != 0x80 {
+			t.Errorf("%s: variant: got %x want 10xxxxxx", test.name, u[8])
+		}
+	}
+
+	// time ordered IDs sort by their creation time
+	a, err := NewULID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(a[:6], b[:6]) > 0 {
+		t.Errorf("timestamps: %x was after %x", a[:6], b[:6])
+	}
+}